@@ -1,21 +1,27 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"gorm-test/internal/auth"
 	"gorm-test/internal/config"
 	"gorm-test/internal/database"
+	"gorm-test/internal/filter"
 	"gorm-test/internal/handler"
+	"gorm-test/internal/mailer"
+	"gorm-test/internal/oidc"
 	"gorm-test/internal/repository"
 	"gorm-test/internal/router"
 	"gorm-test/internal/service"
 	"gorm-test/middleware"
 	"gorm-test/pkg/notify"
 	"gorm-test/pkg/sentry"
+	"gorm-test/pkg/validator"
 	"log"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -32,6 +38,7 @@ func main() {
 
 	// Gin 모드 설정
 	gin.SetMode(cfg.Server.Mode)
+	validator.RegisterGinValidations()
 
 	// 데이터베이스 연결
 	db, err := database.Init(&cfg.Database)
@@ -39,21 +46,196 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Redis 연결 (세션/토큰 저장소, 로그인 잠금, Rate Limit) - 모두 memory 저장소면 연결하지 않음
+	var redisClient *redis.Client
+	if cfg.Auth.TokenStore != "memory" || cfg.Auth.Lockout.Store != "memory" || cfg.RateLimit.Store != "memory" {
+		redisClient, err = database.NewRedis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var tokenStore auth.TokenStore
+	if cfg.Auth.TokenStore == "memory" {
+		tokenStore = auth.NewMemoryTokenStore(time.Minute)
+	} else {
+		tokenStore = auth.NewRedisTokenStore(redisClient)
+	}
+
+	// Rate Limit (라우트 그룹별 정책, redis면 모든 인스턴스가 한도 공유)
+	var rateLimitStore middleware.RateLimitStore
+	if cfg.RateLimit.Store == "memory" {
+		rateLimitStore = middleware.NewMemoryRateLimitStore()
+	} else {
+		rateLimitStore = middleware.NewRedisRateLimitStore(redisClient)
+	}
+	rateLimitPolicies := make([]middleware.RateLimitPolicy, 0, len(cfg.RateLimit.Policies))
+	for name, policy := range cfg.RateLimit.Policies {
+		failOpen := cfg.RateLimit.FailOpen
+		if policy.FailOpen != nil {
+			failOpen = *policy.FailOpen
+		}
+		rateLimitPolicies = append(rateLimitPolicies, middleware.RateLimitPolicy{
+			Name:     name,
+			Limit:    policy.Limit,
+			Period:   policy.Period,
+			Burst:    policy.Burst,
+			KeyBy:    middleware.RateLimitKeyBy(policy.Key),
+			FailOpen: failOpen,
+		})
+	}
+	rateLimits, err := middleware.NewRateLimits(rateLimitStore, rateLimitPolicies)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// 의존성 주입
+	userRepo := repository.NewUserRepository(db)
+
+	// 스팸/어뷰징 필터
+	contentFilter, err := filter.NewPipelineFromConfig(cfg.ContentFilter, userRepo)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	postRepo := repository.NewPostRepository(db)
-	postService := service.NewPostService(postRepo, cfg)
+	commentRepo := repository.NewCommentRepository(db)
+
+	// 게시글/댓글 수 메트릭 초기화
+	if err := service.SeedBoardMetrics(postRepo, commentRepo); err != nil {
+		log.Printf("메트릭 초기화 실패: %v", err)
+	}
+
+	// JWT 서명 키 (kid별 키, 주기적 교체)
+	var keyStore auth.KeyStore = auth.NewMemoryKeyStore()
+	if cfg.JWT.KeysDir != "" {
+		keyStore = auth.NewFileKeyStore(cfg.JWT.KeysDir)
+	}
+	keyManager, err := auth.NewKeyManager(context.Background(), keyStore, auth.KeyManagerConfig{
+		Algorithm:        cfg.JWT.Algorithm,
+		RotationInterval: cfg.JWT.RotationInterval,
+		GracePeriod:      max(cfg.JWT.GracePeriod, cfg.JWT.RefreshExpiry), // 교체 전 발급된 Refresh Token도 검증되도록
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	go keyManager.Run(context.Background(), time.Minute)
+
+	tokenService := auth.NewTokenService(keyManager, cfg.JWT.AccessExpiry, cfg.JWT.RefreshExpiry, tokenStore)
+
+	// 보안 감사 로그 (추가만 가능, 해시 체인)
+	auditService := service.NewAuditService(repository.NewAuditRepository(db))
+	auditHandler := handler.NewAuditHandler(auditService)
+
+	// 역할/권한 (DB에서 관리, 기본 역할은 시작 시 생성)
+	roleService := service.NewRoleService(repository.NewRoleRepository(db), userRepo, tokenService, auditService)
+	if err := roleService.EnsureDefaultRoles(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+	roleHandler := handler.NewRoleHandler(roleService)
+
+	postService := service.NewPostService(postRepo, cfg, contentFilter, roleService)
 	postHandler := handler.NewPostHandler(postService)
 
-	commentRepo := repository.NewCommentRepository(db)
-	commentService := service.NewCommentService(commentRepo, postRepo)
+	commentService := service.NewCommentService(commentRepo, postRepo, contentFilter, roleService)
 	commentHandler := handler.NewCommentHandler(commentService)
 
-	userRepo := repository.NewUserRepository(db)
-	tokenService := auth.NewTokenService("secreykkkkkkkkkkkkey", 1, 2)
-	passwordService := auth.NewPasswordService()
-	authService := service.NewAuthService(userRepo, passwordService, tokenService)
-	authHandler := handler.NewAuthHandler(authService) // 라우터 설정
-	r := router.NewRouter(postHandler, commentHandler, authHandler)
+	// 비밀번호 해시 (기존 bcrypt 해시는 검증 후 로그인 시 교체)
+	bcryptHasher := auth.NewBcryptHasher(cfg.Auth.PasswordHash.BcryptCost)
+	argon2Hasher := auth.NewArgon2idHasher(cfg.Auth.PasswordHash.Argon2Memory, cfg.Auth.PasswordHash.Argon2Iterations, cfg.Auth.PasswordHash.Argon2Parallelism)
+	passwordService := auth.NewPasswordServiceWithHasher(argon2Hasher, bcryptHasher)
+	if cfg.Auth.PasswordHash.Algorithm == auth.HashBcrypt {
+		passwordService = auth.NewPasswordServiceWithHasher(bcryptHasher, argon2Hasher)
+	}
+	if policy := cfg.Auth.PasswordPolicy; policy.MinLength > 0 {
+		passwordService.SetPolicy(auth.PasswordPolicy{
+			MinLength:      policy.MinLength,
+			MaxLength:      policy.MaxLength,
+			RequireUpper:   policy.RequireUpper,
+			RequireLower:   policy.RequireLower,
+			RequireDigit:   policy.RequireDigit,
+			RequireSpecial: policy.RequireSpecial,
+			ForbidUserInfo: policy.ForbidUserInfo,
+			Blocklist:      policy.Blocklist,
+		})
+	}
+
+	// 메일 발송 (인증 메일 등)
+	mail, err := mailer.NewFromConfig(cfg.Mail)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 로그인 실패 잠금
+	var attemptStore auth.AttemptStore
+	if cfg.Auth.Lockout.Store == "memory" {
+		attemptStore = auth.NewMemoryAttemptStore()
+	} else {
+		attemptStore = auth.NewRedisAttemptStore(redisClient)
+	}
+	loginGuard := auth.NewLoginGuard(attemptStore, auth.LockoutPolicy{
+		MaxAttempts:   cfg.Auth.Lockout.MaxAttempts,
+		MaxIPAttempts: cfg.Auth.Lockout.MaxIPAttempts,
+		BaseLockout:   cfg.Auth.Lockout.BaseLockout,
+		MaxLockout:    cfg.Auth.Lockout.MaxLockout,
+		Window:        cfg.Auth.Lockout.Window,
+	})
+
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	identityRepo := repository.NewUserIdentityRepository(db)
+	personalTokenRepo := repository.NewPersonalAccessTokenRepository(db)
+	loginEventRepo := repository.NewLoginEventRepository(db)
+
+	// 새 기기 로그인 알림 (nil이면 기록만)
+	var loginNotifier service.LoginNotifier
+	if cfg.Auth.NewDeviceAlert {
+		loginNotifier = service.NewMailLoginNotifier(mail)
+	}
+	authService := service.NewAuthService(userRepo, userTokenRepo, recoveryCodeRepo, identityRepo, personalTokenRepo, loginEventRepo,
+		passwordService, tokenService, roleService, loginGuard, mail, loginNotifier, auditService, cfg.Auth)
+
+	// 관리자 사용자 관리
+	adminUserService := service.NewAdminUserService(userRepo, personalTokenRepo, tokenService, roleService, auditService)
+	adminUserHandler := handler.NewAdminUserHandler(adminUserService)
+
+	// 내 계정 관리 (탈퇴 유예 기간이 지난 계정은 주기적으로 익명화)
+	userService := service.NewUserService(userRepo, personalTokenRepo, loginEventRepo, passwordService, tokenService, roleService, loginGuard, auditService, cfg.Auth)
+	userHandler := handler.NewUserHandler(userService)
+	go service.RunAccountPurger(context.Background(), userService, time.Hour)
+
+	// 브라우저 쿠키 세션 (Refresh Token 쿠키는 인증 API에만 전송)
+	sessionCookies := middleware.SessionCookies{
+		Domain:      cfg.Auth.Cookie.Domain,
+		Secure:      cfg.Server.Mode == "release",
+		SameSite:    middleware.ParseSameSite(cfg.Auth.Cookie.SameSite),
+		MaxAge:      cfg.JWT.RefreshExpiry,
+		RefreshPath: "/api/v1/api/auths",
+	}
+
+	// AuthMiddleware에서 Personal Access Token 허용
+	tokenService.SetPersonalTokenVerifier(authService)
+	authHandler := handler.NewAuthHandler(authService, tokenService, sessionCookies) // 라우터 설정
+
+	// 외부 IdP 로그인
+	oidcProviders, err := oidc.NewProvidersFromConfig(cfg.OIDC)
+	if err != nil {
+		log.Fatal(err)
+	}
+	oidcHandler := handler.NewOIDCHandler(authService, oidcProviders,
+		oidc.NewStateCodec(cfg.OIDC.StateSecret, 10*time.Minute), sessionCookies)
+
+	// 내부 서비스용 토큰 확인/폐기
+	oauthClients := make(map[string]string, len(cfg.OAuth.Clients))
+	for _, client := range cfg.OAuth.Clients {
+		oauthClients[client.ClientID] = client.ClientSecret
+	}
+	oauthHandler := handler.NewOAuthHandler(service.NewTokenIntrospectionService(tokenService, auditService))
+
+	r := router.NewRouter(postHandler, commentHandler, authHandler, oidcHandler, roleHandler, adminUserHandler, userHandler, auditHandler, oauthHandler)
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies, cfg.Server.TrustedPlatform); err != nil {
+		log.Fatal(err)
+	}
 
 	corsConfig := middleware.CORSConfig{
 		Debug: cfg.Server.Env == "development",
@@ -72,17 +254,15 @@ func main() {
 			notify.SendToSlack(slackWebhook)(err, stack)
 		},
 	}
+	r.Use(middleware.RequestID())
+	r.Use(middleware.CaptureRequestInfo()) // 감사 로그용 IP/User-Agent/요청 ID
 	r.Use(middleware.CORS(corsConfig))
-	r.Use(middleware.RateLimiter(10, 20))
+	r.Use(rateLimits.For("global"))
 	r.Use(middleware.SecureHeaders(middleware.DefaultSecureConfig(cfg)))
 
-	ipLimiter := middleware.NewIPRateLimiter(5, 10, time.Hour)
-	r.Use(ipLimiter.Middleware())
-
 	if cfg.Server.Env == "development" {
 		r.Use(
 			middleware.BodyLogging(1024),
-			middleware.RequestID(),
 			middleware.Prometheus(), // 메트릭 수집
 			middleware.Logging(),
 			middleware.Recovery(recoveryConfig),
@@ -90,7 +270,7 @@ func main() {
 		) // 1KB 제한
 	}
 
-	engine := r.Setup()
+	engine := r.Setup(tokenService, roleService, auth.NewClientCredentials(oauthClients), rateLimits, cfg.Auth.RequireAdmin2FA)
 
 	// 서버 시작
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
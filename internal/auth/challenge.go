@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// challengeAudience는 2단계 인증 대기 토큰의 aud 클레임입니다.
+// Access Token과 audience가 달라 API 호출에는 사용할 수 없습니다.
+const challengeAudience = "2fa-challenge"
+
+// ChallengeClaims는 비밀번호 확인 후 2단계 인증을 기다리는 토큰의 페이로드입니다.
+type ChallengeClaims struct {
+	DeviceName string `json:"device_name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// UserID는 Subject의 사용자 ID를 반환합니다.
+func (c *ChallengeClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(id), nil
+}
+
+// GenerateChallengeToken은 2단계 인증 대기 토큰을 생성합니다.
+func (s *TokenService) GenerateChallengeToken(userID uint, deviceName string, expiry time.Duration) (string, error) {
+	now := time.Now()
+
+	claims := ChallengeClaims{
+		DeviceName: deviceName,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   fmt.Sprintf("%d", userID),
+			Audience:  jwt.ClaimStrings{challengeAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        generateTokenID(),
+		},
+	}
+
+	return s.sign(claims)
+}
+
+// ValidateChallengeToken은 2단계 인증 대기 토큰을 검증합니다.
+// 이미 사용된 토큰(ConsumeChallengeToken)이면 ErrInvalidToken을 반환합니다.
+func (s *TokenService) ValidateChallengeToken(ctx context.Context, tokenString string) (*ChallengeClaims, error) {
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&ChallengeClaims{},
+		s.keyFunc,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(challengeAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, s.handleTokenError(err)
+	}
+
+	claims, ok := token.Claims.(*ChallengeClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if s.tokenStore != nil {
+		used, err := s.tokenStore.IsBlacklisted(ctx, claims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	return claims, nil
+}
+
+// ConsumeChallengeToken은 2단계 인증에 성공한 대기 토큰을 다시 쓰지 못하게 합니다.
+func (s *TokenService) ConsumeChallengeToken(ctx context.Context, claims *ChallengeClaims) error {
+	if s.tokenStore == nil {
+		return nil
+	}
+
+	expiry := time.Until(claims.ExpiresAt.Time)
+	if expiry <= 0 {
+		return nil
+	}
+	return s.tokenStore.AddToBlacklist(ctx, claims.ID, expiry)
+}
@@ -2,7 +2,7 @@ package auth
 
 import (
 	"errors"
-	"fmt"
+	"slices"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -13,16 +13,44 @@ type CustomClaims struct {
 	Email                string `json:"email"`
 	Username             string `json:"username"`
 	Role                 string `json:"role"`
+	EmailVerified        bool   `json:"email_verified"`
+	SessionID            string `json:"sid,omitempty"` // 토큰을 발급한 세션
+	TwoFactor            bool   `json:"2fa,omitempty"` // 2단계 인증을 거친 세션 여부
 	jwt.RegisteredClaims        // exp iat sub 등을 자동 상속
+
+	// 관리자가 대리 로그인한 토큰이면 관리자 정보 (일반 토큰은 nil)
+	Actor *ActorClaim `json:"act,omitempty"`
+
+	// Personal Access Token으로 인증한 경우에만 설정 (JWT에는 포함되지 않음)
+	PersonalTokenID uint     `json:"-"`
+	Scopes          []string `json:"-"`
+}
+
+// IsPersonalToken은 Personal Access Token으로 인증한 요청인지 반환합니다.
+func (c *CustomClaims) IsPersonalToken() bool {
+	return c.PersonalTokenID != 0
+}
+
+// IsImpersonated는 관리자가 대리 로그인한 토큰인지 반환합니다.
+func (c *CustomClaims) IsImpersonated() bool {
+	return c.Actor != nil
+}
+
+// HasScope는 scope 허용 여부를 반환합니다.
+// 로그인으로 발급된 JWT는 사용자 권한을 모두 가지고, Personal Access Token은 발급 시 지정한 scope만 가집니다.
+func (c *CustomClaims) HasScope(scope string) bool {
+	if !c.IsPersonalToken() {
+		return true
+	}
+	return slices.Contains(c.Scopes, scope)
 }
 
 // Validate는 커스텀 검증 로직을 수행합니다.
 // jwt.ClaimsValidator 인터페이스 구현
 func (c CustomClaims) Validate() error {
-	// Role 검증
-	validRoles := map[string]bool{"user": true, "admin": true}
-	if !validRoles[c.Role] {
-		return fmt.Errorf("invalid role: %s", c.Role)
+	// Role 검증 (역할은 DB에서 관리하므로 존재 여부만 확인하고, 권한은 PermissionChecker로 확인)
+	if c.Role == "" {
+		return errors.New("role is required")
 	}
 
 	// UserID 검증
@@ -35,5 +63,10 @@ func (c CustomClaims) Validate() error {
 		return errors.New("email is required")
 	}
 
+	// 대리 로그인 - 관리자 정보 필수, 자기 자신은 불가
+	if c.Actor != nil && (c.Actor.UserID == 0 || c.Actor.UserID == c.UserID) {
+		return errors.New("invalid act claim")
+	}
+
 	return nil
 }
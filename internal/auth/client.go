@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// ClientCredentials는 토큰 확인(introspection)/폐기(revocation) API를 호출할 수 있는
+// 내부 서비스의 client_id와 client_secret 목록입니다.
+type ClientCredentials struct {
+	secrets map[string][sha256.Size]byte // client_id -> client_secret 해시
+}
+
+// NewClientCredentials는 client_id -> client_secret 맵으로 ClientCredentials를 만듭니다.
+// 비밀값이 비어 있는 클라이언트는 등록하지 않습니다.
+func NewClientCredentials(clients map[string]string) *ClientCredentials {
+	secrets := make(map[string][sha256.Size]byte, len(clients))
+	for id, secret := range clients {
+		if id == "" || secret == "" {
+			continue
+		}
+		secrets[id] = sha256.Sum256([]byte(secret))
+	}
+	return &ClientCredentials{secrets: secrets}
+}
+
+// Authenticate는 client_id와 client_secret이 맞는지 확인합니다.
+// 없는 클라이언트도 같은 비교를 거쳐 응답 시간으로 등록 여부를 알 수 없게 합니다.
+func (c *ClientCredentials) Authenticate(clientID, clientSecret string) bool {
+	expected, ok := c.secrets[clientID]
+	actual := sha256.Sum256([]byte(clientSecret))
+	match := subtle.ConstantTimeCompare(expected[:], actual[:]) == 1
+	return ok && match
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ActorClaim은 다른 사용자로 대리 로그인한 관리자입니다. (RFC 8693 act 클레임)
+type ActorClaim struct {
+	Subject string `json:"sub"`
+	UserID  uint   `json:"user_id"`
+	Email   string `json:"email"`
+}
+
+// GenerateImpersonationToken은 관리자가 subject 사용자로 요청하는 Access Token을 생성합니다.
+//
+//	Refresh Token 없이 expiry 동안만 유효하고, 관리자의 세션(actorSessionID)에 묶여
+//	관리자가 로그아웃하거나 세션이 종료되면 함께 무효화됩니다.
+func (s *TokenService) GenerateImpersonationToken(subject TokenSubject, actor ActorClaim, actorSessionID string, expiry time.Duration) (string, error) {
+	now := time.Now()
+
+	claims := CustomClaims{
+		UserID:        subject.UserID,
+		Email:         subject.Email,
+		Username:      subject.Username,
+		Role:          subject.Role,
+		EmailVerified: subject.EmailVerified,
+		SessionID:     actorSessionID,
+		Actor:         &actor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   fmt.Sprintf("%d", subject.UserID),
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        generateTokenID(),
+		},
+	}
+
+	return s.sign(claims)
+}
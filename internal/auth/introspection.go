@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// 토큰 종류 (RFC 7662/7009의 token_type_hint 값)
+const (
+	TokenTypeHintAccess  = "access_token"
+	TokenTypeHintRefresh = "refresh_token"
+)
+
+var (
+	ErrUnsupportedTokenType = errors.New("unsupported token type")
+)
+
+// IntrospectAccessToken은 Access Token(또는 Personal Access Token)이 지금 사용 가능한지 확인합니다.
+// AuthMiddleware와 같은 기준으로 검증하며, 블랙리스트에 있으면 ErrInvalidToken입니다.
+func (s *TokenService) IntrospectAccessToken(ctx context.Context, tokenString string) (*CustomClaims, error) {
+	if IsPersonalToken(tokenString) {
+		return s.ValidatePersonalToken(ctx, tokenString)
+	}
+
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.IsRevoked(ctx, claims, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// IntrospectRefreshToken은 Refresh Token이 지금 사용 가능한지 확인하고 세션을 반환합니다.
+// ValidateRefreshToken과 달리 교체된 토큰이어도 세션을 폐기하지 않습니다. (조회만 함)
+func (s *TokenService) IntrospectRefreshToken(ctx context.Context, tokenString string) (*Session, *RefreshClaims, error) {
+	claims, userID, err := s.parseRefreshToken(tokenString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.tokenStore == nil {
+		return &Session{ID: claims.SessionID, UserID: userID, TokenID: claims.ID}, claims, nil
+	}
+
+	session, err := s.findRefreshSession(ctx, claims, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.TokenID != claims.ID {
+		// 이미 교체된 토큰
+		return nil, nil, ErrInvalidToken
+	}
+	return session, claims, nil
+}
+
+// RevokeRefreshToken은 Refresh Token의 세션을 종료합니다. 세션에서 발급된 Access Token도 함께 무효화됩니다.
+// 이미 사용할 수 없는 토큰이면 ErrInvalidToken입니다.
+func (s *TokenService) RevokeRefreshToken(ctx context.Context, tokenString string) (*Session, error) {
+	session, _, err := s.IntrospectRefreshToken(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if s.tokenStore == nil {
+		return session, nil
+	}
+	if err := s.revokeSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLoginLocked = errors.New("login temporarily locked")
+)
+
+// LockScope는 잠금 대상입니다.
+type LockScope string
+
+const (
+	LockScopeAccount LockScope = "account"
+	LockScopeIP      LockScope = "ip"
+)
+
+// LockedError는 로그인 시도가 잠겨 있을 때 반환됩니다. (errors.Is(err, ErrLoginLocked))
+type LockedError struct {
+	Scope      LockScope
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("login locked (%s) for %s", e.Scope, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+// AttemptStore는 로그인 실패 횟수와 잠금 상태 저장소입니다.
+type AttemptStore interface {
+	// IncrFailures는 실패 횟수를 1 늘리고 새 값을 반환합니다. 마지막 실패 후 window 동안 유지됩니다.
+	IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	// ResetFailures는 실패 횟수를 초기화합니다.
+	ResetFailures(ctx context.Context, key string) error
+	// Lock은 key를 duration 동안 잠급니다.
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockRemaining은 남은 잠금 시간을 반환합니다. 잠겨 있지 않으면 0입니다.
+	LockRemaining(ctx context.Context, key string) (time.Duration, error)
+	// Unlock은 잠금을 해제합니다.
+	Unlock(ctx context.Context, key string) error
+}
+
+// LockoutPolicy는 잠금 정책입니다.
+//
+//	실패가 MaxAttempts번에 도달하면 BaseLockout 동안 잠그고,
+//	이후 실패할 때마다 잠금 시간을 두 배로 늘립니다. (최대 MaxLockout)
+type LockoutPolicy struct {
+	MaxAttempts   int           // 계정당 허용 실패 횟수
+	MaxIPAttempts int           // IP당 허용 실패 횟수 (여러 계정 대상 공격 대응)
+	BaseLockout   time.Duration // 첫 잠금 시간
+	MaxLockout    time.Duration // 최대 잠금 시간
+	Window        time.Duration // 실패 횟수 유지 시간 (마지막 실패 기준)
+}
+
+// LoginGuard는 무차별 대입 공격을 막기 위해 로그인 실패를 계정/IP별로 추적합니다.
+// nil LoginGuard는 아무것도 제한하지 않습니다.
+type LoginGuard struct {
+	store  AttemptStore
+	policy LockoutPolicy
+}
+
+// NewLoginGuard 생성자
+func NewLoginGuard(store AttemptStore, policy LockoutPolicy) *LoginGuard {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 5
+	}
+	if policy.MaxIPAttempts <= 0 {
+		policy.MaxIPAttempts = 20
+	}
+	if policy.BaseLockout <= 0 {
+		policy.BaseLockout = time.Minute
+	}
+	if policy.MaxLockout < policy.BaseLockout {
+		policy.MaxLockout = policy.BaseLockout
+	}
+	if policy.Window <= 0 {
+		policy.Window = time.Hour
+	}
+	return &LoginGuard{store: store, policy: policy}
+}
+
+// Check는 계정 또는 IP가 잠겨 있으면 *LockedError를 반환합니다.
+func (g *LoginGuard) Check(ctx context.Context, email, ip string) error {
+	if g == nil {
+		return nil
+	}
+
+	for _, t := range g.targets(email, ip) {
+		remaining, err := g.store.LockRemaining(ctx, lockKey(t.scope, t.id))
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return &LockedError{Scope: t.scope, RetryAfter: remaining}
+		}
+	}
+	return nil
+}
+
+// RecordFailure는 실패를 기록합니다. 이번 실패로 잠기면 *LockedError를 반환합니다.
+func (g *LoginGuard) RecordFailure(ctx context.Context, email, ip string) error {
+	if g == nil {
+		return nil
+	}
+
+	var locked *LockedError
+	for _, t := range g.targets(email, ip) {
+		failures, err := g.store.IncrFailures(ctx, failureKey(t.scope, t.id), g.policy.Window)
+		if err != nil {
+			return err
+		}
+
+		duration := g.lockoutFor(failures, t.max)
+		if duration <= 0 {
+			continue
+		}
+		if err := g.store.Lock(ctx, lockKey(t.scope, t.id), duration); err != nil {
+			return err
+		}
+		if locked == nil || duration > locked.RetryAfter {
+			locked = &LockedError{Scope: t.scope, RetryAfter: duration}
+		}
+	}
+
+	if locked != nil {
+		return locked
+	}
+	return nil
+}
+
+// RecordSuccess는 로그인에 성공한 계정의 실패 횟수를 초기화합니다.
+// IP 실패 횟수는 유지합니다. (한 IP에서 여러 계정을 시도하는 공격 대응)
+func (g *LoginGuard) RecordSuccess(ctx context.Context, email string) error {
+	if g == nil {
+		return nil
+	}
+	return g.store.ResetFailures(ctx, failureKey(LockScopeAccount, normalizeEmail(email)))
+}
+
+// Unlock은 계정 잠금과 실패 횟수를 초기화합니다. (관리자 해제)
+func (g *LoginGuard) Unlock(ctx context.Context, email string) error {
+	if g == nil {
+		return nil
+	}
+
+	id := normalizeEmail(email)
+	if err := g.store.Unlock(ctx, lockKey(LockScopeAccount, id)); err != nil {
+		return err
+	}
+	return g.store.ResetFailures(ctx, failureKey(LockScopeAccount, id))
+}
+
+// lockoutFor는 실패 횟수에 따른 잠금 시간을 계산합니다. (지수 백오프)
+func (g *LoginGuard) lockoutFor(failures int64, max int) time.Duration {
+	over := failures - int64(max)
+	if over < 0 {
+		return 0
+	}
+
+	// BaseLockout * 2^over (오버플로 방지를 위해 MaxLockout에서 자름)
+	if over > 30 {
+		return g.policy.MaxLockout
+	}
+	duration := g.policy.BaseLockout << over
+	if duration <= 0 || duration > g.policy.MaxLockout {
+		return g.policy.MaxLockout
+	}
+	return duration
+}
+
+type lockTarget struct {
+	scope LockScope
+	id    string
+	max   int
+}
+
+func (g *LoginGuard) targets(email, ip string) []lockTarget {
+	targets := []lockTarget{{LockScopeAccount, normalizeEmail(email), g.policy.MaxAttempts}}
+	if ip != "" {
+		targets = append(targets, lockTarget{LockScopeIP, ip, g.policy.MaxIPAttempts})
+	}
+	return targets
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// 키 형식
+//
+//	login_failures:{scope}:{id}
+//	login_lock:{scope}:{id}
+func failureKey(scope LockScope, id string) string {
+	return fmt.Sprintf("login_failures:%s:%s", scope, id)
+}
+
+func lockKey(scope LockScope, id string) string {
+	return fmt.Sprintf("login_lock:%s:%s", scope, id)
+}
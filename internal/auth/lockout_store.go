@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisAttemptStore는 Redis 기반 AttemptStore입니다. 여러 서버가 실패 횟수를 공유합니다.
+type RedisAttemptStore struct {
+	client *redis.Client
+}
+
+func NewRedisAttemptStore(client *redis.Client) *RedisAttemptStore {
+	return &RedisAttemptStore{client: client}
+}
+
+func (s *RedisAttemptStore) IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (s *RedisAttemptStore) ResetFailures(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+func (s *RedisAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	return s.client.Set(ctx, key, "1", duration).Err()
+}
+
+func (s *RedisAttemptStore) LockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2: 키 없음, -1: 만료 없음 (잠금 키는 항상 만료가 있으므로 잠금 아님으로 처리)
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *RedisAttemptStore) Unlock(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// MemoryAttemptStore는 메모리 기반 AttemptStore입니다.
+// 단일 인스턴스 전용 - 여러 대로 운영하면 RedisAttemptStore를 사용해야 합니다.
+type MemoryAttemptStore struct {
+	mu       sync.Mutex
+	failures map[string]memoryCounter
+	locks    map[string]time.Time // key -> 잠금 해제 시각
+
+	nextCleanup time.Time
+}
+
+type memoryCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{
+		failures: make(map[string]memoryCounter),
+		locks:    make(map[string]time.Time),
+	}
+}
+
+func (s *MemoryAttemptStore) IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	counter := s.failures[key]
+	if now.After(counter.expiresAt) {
+		counter = memoryCounter{}
+	}
+	counter.count++
+	counter.expiresAt = now.Add(window)
+	s.failures[key] = counter
+
+	s.cleanup(now)
+	return counter.count, nil
+}
+
+func (s *MemoryAttemptStore) ResetFailures(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	return nil
+}
+
+func (s *MemoryAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks[key] = time.Now().Add(duration)
+	return nil
+}
+
+func (s *MemoryAttemptStore) LockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.locks[key]
+	if !ok {
+		return 0, nil
+	}
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(s.locks, key)
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+func (s *MemoryAttemptStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.locks, key)
+	return nil
+}
+
+// cleanup은 1분에 한 번 만료된 항목을 정리합니다. (호출 시 lock 필요)
+func (s *MemoryAttemptStore) cleanup(now time.Time) {
+	if now.Before(s.nextCleanup) {
+		return
+	}
+	s.nextCleanup = now.Add(time.Minute)
+
+	for key, counter := range s.failures {
+		if now.After(counter.expiresAt) {
+			delete(s.failures, key)
+		}
+	}
+	for key, until := range s.locks {
+		if now.After(until) {
+			delete(s.locks, key)
+		}
+	}
+}
@@ -2,97 +2,94 @@ package auth
 
 import (
 	"errors"
-	"unicode"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrPasswordTooShort        = errors.New("password must be at least 8 characters")
-	ErrPasswordNoUpper         = errors.New("password must contain at least one uppercase letter")
-	ErrPasswordNoLower         = errors.New("password must contain at least one lowercase letter")
-	ErrPasswordNoDigit         = errors.New("password must contain at least one digit")
-	ErrPasswordNoSpecial       = errors.New("password must contain at least one special character")
-	ErrPasswordMismatch  error = errors.New("password mismatch")
+	ErrPasswordTooShort               = errors.New("password is too short")
+	ErrPasswordTooLong                = errors.New("password is too long")
+	ErrPasswordNoUpper                = errors.New("password must contain at least one uppercase letter")
+	ErrPasswordNoLower                = errors.New("password must contain at least one lowercase letter")
+	ErrPasswordNoDigit                = errors.New("password must contain at least one digit")
+	ErrPasswordNoSpecial              = errors.New("password must contain at least one special character")
+	ErrPasswordContainsUserInfo       = errors.New("password must not contain the email or username")
+	ErrPasswordCommon                 = errors.New("password is too common")
+	ErrPasswordMismatch         error = errors.New("password mismatch")
 )
 
 const (
 	MinPasswordLength = 8
+	MaxPasswordLength = 128
 	DefaultCost       = 12 // 2^12 = 4096 iterations  || 10	~100ms	개발 환경 || 12	~400ms	일반 서비스 || 14	~1.5s	높은 보안
 
 )
 
 // PasswordService는 비밀번호 해싱과 검증을 담당합니다.
+//
+//	새 해시는 hasher로 만들고, 검증은 해시 문자열의 알고리즘 식별자로 hasher를 골라서 합니다.
+//	그래서 알고리즘을 바꿔도 기존 해시로 로그인할 수 있고, 로그인 시 NeedsRehash로 새 해시로 교체합니다.
 type PasswordService struct {
-	cost int
+	hasher  PasswordHasher
+	hashers map[string]PasswordHasher // 알고리즘 식별자 -> 검증용 hasher
+	policy  PasswordPolicy
 }
 
-// NewPasswordService는 PasswordService를 생성합니다.
+// NewPasswordService는 argon2id로 해싱하고 기존 bcrypt 해시도 검증하는 PasswordService를 생성합니다.
 func NewPasswordService() *PasswordService {
-	return &PasswordService{
-		cost: DefaultCost,
-	}
+	return NewPasswordServiceWithHasher(NewArgon2idHasher(0, 0, 0), NewBcryptHasher(DefaultCost))
 }
 
-// Hash는 비밀번호를 해싱합니다.
-func (s *PasswordService) Hash(password string) (string, error) {
-	if len(password) < MinPasswordLength {
-		return "", ErrPasswordTooShort
+// NewPasswordServiceWithHasher는 hasher로 해싱하는 PasswordService를 생성합니다.
+// legacy는 기존 해시 검증에만 사용할 알고리즘입니다.
+func NewPasswordServiceWithHasher(hasher PasswordHasher, legacy ...PasswordHasher) *PasswordService {
+	hashers := make(map[string]PasswordHasher, len(legacy)+1)
+	for _, h := range legacy {
+		hashers[h.ID()] = h
 	}
+	hashers[hasher.ID()] = hasher
 
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
-	if err != nil {
-		return "", err
+	return &PasswordService{
+		hasher:  hasher,
+		hashers: hashers,
+		policy:  DefaultPasswordPolicy(),
 	}
-
-	return string(hashedBytes), nil
 }
 
-// Compare는 평문 비밀번호와 해시된 비밀번호를 비교합니다.
-func (s *PasswordService) Compare(hashedPassword, password string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-	if err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return ErrPasswordMismatch
-		}
-		return err
-	}
-	return nil
+// SetPolicy는 비밀번호 정책을 변경합니다.
+func (s *PasswordService) SetPolicy(policy PasswordPolicy) {
+	s.policy = policy
 }
 
-// ValidatePassword는 비밀번호 정책을 검사합니다.
-func (s *PasswordService) ValidatePassword(password string) error {
-	if len(password) < MinPasswordLength {
-		return ErrPasswordTooShort
-	}
+// Policy는 현재 비밀번호 정책을 반환합니다.
+func (s *PasswordService) Policy() PasswordPolicy {
+	return s.policy
+}
 
-	var hasUpper, hasLower, hasDigit, hasSpecial bool
+// Hash는 비밀번호를 해싱합니다.
+// 정책 검사는 하지 않으므로 사용자가 입력한 비밀번호는 ValidatePassword를 먼저 호출해야 합니다.
+func (s *PasswordService) Hash(password string) (string, error) {
+	return s.hasher.Hash(password)
+}
 
-	for _, char := range password {
-		switch {
-		case unicode.IsUpper(char):
-			hasUpper = true
-		case unicode.IsLower(char):
-			hasLower = true
-		case unicode.IsDigit(char):
-			hasDigit = true
-		case unicode.IsPunct(char) || unicode.IsSymbol(char):
-			hasSpecial = true
-		}
+// Compare는 평문 비밀번호와 해시된 비밀번호를 비교합니다.
+func (s *PasswordService) Compare(hashedPassword, password string) error {
+	hasher, ok := s.hashers[hashID(hashedPassword)]
+	if !ok {
+		return ErrUnknownHashFormat
 	}
+	return hasher.Verify(hashedPassword, password)
+}
 
-	if !hasUpper {
-		return ErrPasswordNoUpper
-	}
-	if !hasLower {
-		return ErrPasswordNoLower
-	}
-	if !hasDigit {
-		return ErrPasswordNoDigit
-	}
-	if !hasSpecial {
-		return ErrPasswordNoSpecial
+// NeedsRehash는 해시가 현재 알고리즘/파라미터와 다른지 확인합니다.
+// 평문 비밀번호를 알 수 있는 로그인 성공 시점에 새 해시로 교체합니다.
+func (s *PasswordService) NeedsRehash(hashedPassword string) bool {
+	if hashID(hashedPassword) != s.hasher.ID() {
+		return true
 	}
+	return s.hasher.NeedsRehash(hashedPassword)
+}
 
-	return nil
+// ValidatePassword는 비밀번호 정책을 검사합니다.
+// userInputs는 비밀번호에 포함되면 안 되는 사용자 정보(이메일, 사용자 이름)입니다.
+func (s *PasswordService) ValidatePassword(password string, userInputs ...string) error {
+	return s.policy.Validate(password, userInputs...)
 }
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+)
+
+// 해시 알고리즘 식별자 (PHC 문자열의 첫 필드)
+const (
+	HashArgon2id = "argon2id"
+	HashBcrypt   = "bcrypt"
+)
+
+// PasswordHasher는 비밀번호 해시 알고리즘입니다.
+//
+//	해시는 "$<id>$<파라미터>$<salt>$<hash>" 형식(PHC string format)으로 저장해서
+//	알고리즘과 파라미터를 해시 문자열만으로 알 수 있게 합니다.
+type PasswordHasher interface {
+	// ID는 알고리즘 식별자입니다.
+	ID() string
+	// Hash는 새 salt로 비밀번호를 해싱합니다.
+	Hash(password string) (string, error)
+	// Verify는 비밀번호가 해시와 일치하는지 확인합니다. 불일치는 ErrPasswordMismatch입니다.
+	Verify(encoded, password string) error
+	// NeedsRehash는 해시의 파라미터가 현재 설정과 다른지 확인합니다.
+	NeedsRehash(encoded string) bool
+}
+
+// hashID는 해시 문자열의 알고리즘 식별자를 반환합니다.
+// bcrypt는 자체 형식($2a$, $2b$, $2y$)을 그대로 사용합니다.
+func hashID(encoded string) string {
+	parts := strings.SplitN(encoded, "$", 3)
+	if len(parts) < 3 || parts[0] != "" {
+		return ""
+	}
+	switch parts[1] {
+	case "2a", "2b", "2y":
+		return HashBcrypt
+	default:
+		return parts[1]
+	}
+}
+
+// Argon2idHasher는 argon2id 해시입니다. (RFC 9106)
+type Argon2idHasher struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// NewArgon2idHasher는 argon2id 해시를 생성합니다. 0인 파라미터는 OWASP 권장 최소값을 사용합니다.
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	if memory == 0 {
+		memory = 19 * 1024 // 19 MiB
+	}
+	if iterations == 0 {
+		iterations = 2
+	}
+	if parallelism == 0 {
+		parallelism = 1
+	}
+	return &Argon2idHasher{
+		Memory:      memory,
+		Iterations:  iterations,
+		Parallelism: parallelism,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+func (h *Argon2idHasher) ID() string {
+	return HashArgon2id
+}
+
+// Hash는 $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash> 형식으로 반환합니다.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		HashArgon2id, argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(encoded, password string) error {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, _, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Memory != h.Memory ||
+		params.Iterations != h.Iterations ||
+		params.Parallelism != h.Parallelism ||
+		uint32(len(key)) != h.KeyLength
+}
+
+// decodeArgon2id는 PHC 문자열에서 파라미터, salt, 해시를 읽습니다.
+func decodeArgon2id(encoded string) (*Argon2idHasher, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != HashArgon2id {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	return params, salt, key, nil
+}
+
+// BcryptHasher는 bcrypt 해시입니다. 기존 해시 검증과 bcrypt를 계속 쓰는 경우에 사용합니다.
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher는 bcrypt 해시를 생성합니다. cost가 0이면 DefaultCost입니다.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = DefaultCost
+	}
+	return &BcryptHasher{Cost: cost}
+}
+
+func (h *BcryptHasher) ID() string {
+	return HashBcrypt
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedBytes), nil
+}
+
+func (h *BcryptHasher) Verify(encoded, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.Cost
+}
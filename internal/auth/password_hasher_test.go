@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 테스트 속도를 위해 최소 파라미터 사용
+func newTestArgon2id() *Argon2idHasher {
+	return NewArgon2idHasher(64, 1, 1)
+}
+
+func TestArgon2idHasher_PHCRoundTrip(t *testing.T) {
+	h := newTestArgon2id()
+
+	encoded, err := h.Hash("Corr3ct-Horse!")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"), encoded)
+	assert.Equal(t, HashArgon2id, hashID(encoded))
+
+	// 파라미터는 해시 문자열에서 읽음
+	params, salt, key, err := decodeArgon2id(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(64), params.Memory)
+	assert.Equal(t, uint32(1), params.Iterations)
+	assert.Equal(t, uint8(1), params.Parallelism)
+	assert.Len(t, salt, int(h.SaltLength))
+	assert.Len(t, key, int(h.KeyLength))
+
+	assert.NoError(t, h.Verify(encoded, "Corr3ct-Horse!"))
+	assert.ErrorIs(t, h.Verify(encoded, "wrong"), ErrPasswordMismatch)
+
+	// 같은 비밀번호도 salt가 달라 해시가 다름
+	again, err := h.Hash("Corr3ct-Horse!")
+	require.NoError(t, err)
+	assert.NotEqual(t, encoded, again)
+}
+
+func TestArgon2idHasher_VerifiesWithStoredParams(t *testing.T) {
+	encoded, err := NewArgon2idHasher(128, 2, 1).Hash("secret")
+	require.NoError(t, err)
+
+	// 현재 설정이 달라도 해시에 기록된 파라미터로 검증하고, 재해싱이 필요하다고 판단
+	current := newTestArgon2id()
+	assert.NoError(t, current.Verify(encoded, "secret"))
+	assert.True(t, current.NeedsRehash(encoded))
+	assert.False(t, NewArgon2idHasher(128, 2, 1).NeedsRehash(encoded))
+}
+
+func TestArgon2idHasher_RejectsMalformedHash(t *testing.T) {
+	h := newTestArgon2id()
+
+	for _, encoded := range []string{
+		"",
+		"plain",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA", // hash 없음
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g", // 다른 버전
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",  // 파라미터 오류
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$!!!",         // base64 오류
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",  // 다른 알고리즘
+	} {
+		assert.ErrorIs(t, h.Verify(encoded, "secret"), ErrUnknownHashFormat, encoded)
+		assert.True(t, h.NeedsRehash(encoded), encoded)
+	}
+}
+
+func TestPasswordService_RehashesBcryptToArgon2id(t *testing.T) {
+	legacy := NewBcryptHasher(bcrypt.MinCost)
+	bcryptHash, err := legacy.Hash("Corr3ct-Horse!")
+	require.NoError(t, err)
+	assert.Equal(t, HashBcrypt, hashID(bcryptHash))
+
+	s := NewPasswordServiceWithHasher(newTestArgon2id(), legacy)
+
+	// 기존 bcrypt 해시로 로그인 가능하고, 새 알고리즘으로 교체 대상
+	require.NoError(t, s.Compare(bcryptHash, "Corr3ct-Horse!"))
+	assert.ErrorIs(t, s.Compare(bcryptHash, "wrong"), ErrPasswordMismatch)
+	assert.True(t, s.NeedsRehash(bcryptHash))
+
+	rehashed, err := s.Hash("Corr3ct-Horse!")
+	require.NoError(t, err)
+	assert.Equal(t, HashArgon2id, hashID(rehashed))
+	assert.NoError(t, s.Compare(rehashed, "Corr3ct-Horse!"))
+	assert.False(t, s.NeedsRehash(rehashed))
+}
+
+func TestPasswordService_UnknownHashFormat(t *testing.T) {
+	// bcrypt를 등록하지 않으면 bcrypt 해시는 검증할 수 없음
+	s := NewPasswordServiceWithHasher(newTestArgon2id())
+
+	bcryptHash, err := NewBcryptHasher(bcrypt.MinCost).Hash("secret")
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Compare(bcryptHash, "secret"), ErrUnknownHashFormat)
+	assert.ErrorIs(t, s.Compare("plaintext", "plaintext"), ErrUnknownHashFormat)
+}
+
+func TestBcryptHasher_NeedsRehashOnCostChange(t *testing.T) {
+	encoded, err := NewBcryptHasher(bcrypt.MinCost).Hash("secret")
+	require.NoError(t, err)
+
+	assert.False(t, NewBcryptHasher(bcrypt.MinCost).NeedsRehash(encoded))
+	assert.True(t, NewBcryptHasher(bcrypt.MinCost+1).NeedsRehash(encoded))
+}
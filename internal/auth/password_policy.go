@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy는 비밀번호 정책입니다.
+type PasswordPolicy struct {
+	MinLength      int  // 최소 길이 (문자 수)
+	MaxLength      int  // 최대 길이 (0이면 제한 없음) - 해싱 비용을 이용한 DoS 방지
+	RequireUpper   bool // 대문자 포함
+	RequireLower   bool // 소문자 포함
+	RequireDigit   bool // 숫자 포함
+	RequireSpecial bool // 특수문자 포함
+	ForbidUserInfo bool // 이메일/사용자 이름 포함 금지
+	Blocklist      bool // 흔히 쓰이거나 유출된 비밀번호 차단
+}
+
+// DefaultPasswordPolicy는 기본 비밀번호 정책을 반환합니다.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      MinPasswordLength,
+		MaxLength:      MaxPasswordLength,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		ForbidUserInfo: true,
+		Blocklist:      true,
+	}
+}
+
+// PasswordPolicyError는 비밀번호 정책 위반 목록입니다.
+//
+//	errors.Is로 개별 위반(ErrPasswordTooShort 등)을 확인할 수 있습니다.
+type PasswordPolicyError struct {
+	Violations []error
+}
+
+func (e *PasswordPolicyError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Error()
+	}
+	return "password policy violation: " + strings.Join(msgs, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() []error {
+	return e.Violations
+}
+
+// Messages는 위반 항목별 사용자 메시지를 반환합니다.
+func (e *PasswordPolicyError) Messages(policy PasswordPolicy) []string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, policy.message(v))
+	}
+	return msgs
+}
+
+func (p PasswordPolicy) message(err error) string {
+	switch err {
+	case ErrPasswordTooShort:
+		return fmt.Sprintf("%d자 이상이어야 합니다", p.MinLength)
+	case ErrPasswordTooLong:
+		return fmt.Sprintf("%d자 이하여야 합니다", p.MaxLength)
+	case ErrPasswordNoUpper:
+		return "대문자가 포함되어야 합니다"
+	case ErrPasswordNoLower:
+		return "소문자가 포함되어야 합니다"
+	case ErrPasswordNoDigit:
+		return "숫자가 포함되어야 합니다"
+	case ErrPasswordNoSpecial:
+		return "특수문자가 포함되어야 합니다"
+	case ErrPasswordContainsUserInfo:
+		return "이메일이나 사용자 이름을 포함할 수 없습니다"
+	case ErrPasswordCommon:
+		return "너무 흔하거나 유출된 적이 있는 비밀번호입니다"
+	default:
+		return "사용할 수 없는 비밀번호입니다"
+	}
+}
+
+// Validate는 비밀번호가 정책을 만족하는지 검사합니다.
+// userInputs는 비밀번호에 포함되면 안 되는 사용자 정보(이메일, 사용자 이름)입니다.
+// 위반 항목을 모두 모아 *PasswordPolicyError로 반환합니다.
+func (p PasswordPolicy) Validate(password string, userInputs ...string) error {
+	var violations []error
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, ErrPasswordTooShort)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, ErrPasswordTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, ErrPasswordNoUpper)
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, ErrPasswordNoLower)
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, ErrPasswordNoDigit)
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, ErrPasswordNoSpecial)
+	}
+
+	if p.ForbidUserInfo && containsUserInfo(password, userInputs) {
+		violations = append(violations, ErrPasswordContainsUserInfo)
+	}
+	if p.Blocklist && IsCommonPassword(password) {
+		violations = append(violations, ErrPasswordCommon)
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// minUserInfoLength보다 짧은 사용자 정보는 검사하지 않습니다. (너무 짧으면 우연히 겹침)
+const minUserInfoLength = 3
+
+// containsUserInfo는 비밀번호에 이메일, 이메일 아이디, 사용자 이름이 포함되어 있는지 대소문자 구분 없이 확인합니다.
+func containsUserInfo(password string, userInputs []string) bool {
+	lower := strings.ToLower(password)
+
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		candidates := []string{input}
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			candidates = append(candidates, local)
+		}
+
+		for _, c := range candidates {
+			if utf8.RuneCountInString(c) >= minUserInfoLength && strings.Contains(lower, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// common_passwords.txt.gz는 흔히 쓰이거나 유출된 비밀번호 목록입니다.
+// 소문자로 정규화하고 정렬해서 gzip으로 압축했습니다. (출처: data/NOTICE)
+//
+//go:embed data/common_passwords.txt.gz
+var commonPasswordsGz []byte
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswords     []string
+)
+
+// loadCommonPasswords는 처음 사용할 때 목록의 압축을 풉니다.
+func loadCommonPasswords() []string {
+	commonPasswordsOnce.Do(func() {
+		r, err := gzip.NewReader(bytes.NewReader(commonPasswordsGz))
+		if err != nil {
+			panic(fmt.Sprintf("auth: invalid common password list: %v", err))
+		}
+		defer r.Close()
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				commonPasswords = append(commonPasswords, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			panic(fmt.Sprintf("auth: invalid common password list: %v", err))
+		}
+	})
+	return commonPasswords
+}
+
+// minCommonBaseLength보다 짧은 기본 단어는 검사하지 않습니다.
+const minCommonBaseLength = 4
+
+// IsCommonPassword는 비밀번호가 흔히 쓰이거나 유출된 비밀번호인지 확인합니다.
+//
+//	대소문자는 구분하지 않고, 정책을 맞추려고 뒤에 붙인 숫자/특수문자("Password1!")를 떼어낸 단어도 확인합니다.
+func IsCommonPassword(password string) bool {
+	list := loadCommonPasswords()
+	lower := strings.ToLower(password)
+
+	if containsSorted(list, lower) {
+		return true
+	}
+
+	base := strings.TrimRightFunc(lower, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	return base != lower && utf8.RuneCountInString(base) >= minCommonBaseLength && containsSorted(list, base)
+}
+
+func containsSorted(list []string, s string) bool {
+	i := sort.SearchStrings(list, s)
+	return i < len(list) && list[i] == s
+}
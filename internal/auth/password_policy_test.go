@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// policyViolations는 Validate가 반환한 위반 목록입니다. (통과하면 nil)
+func policyViolations(t *testing.T, err error) []error {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var policyErr *PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	return policyErr.Violations
+}
+
+func TestPasswordPolicy_Blocklist(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	for _, password := range []string{
+		"password",   // 목록 그대로
+		"PassWord",   // 대소문자 무시
+		"Password1!", // 정책을 맞추려고 붙인 숫자/특수문자
+		"Letmein2024#",
+		"Qwerty123$%",
+	} {
+		assert.True(t, IsCommonPassword(password), password)
+		assert.ErrorIs(t, policy.Validate(password), ErrPasswordCommon, password)
+	}
+
+	for _, password := range []string{
+		"Tr0ub4dor&3-staple",
+		"1!Password", // 앞에 붙이면 기본 단어가 아님
+		"Abc1!",      // 기본 단어가 너무 짧으면 검사하지 않음
+	} {
+		assert.False(t, IsCommonPassword(password), password)
+	}
+
+	// 정책에서 끄면 검사하지 않음
+	policy.Blocklist = false
+	assert.NotContains(t, policyViolations(t, policy.Validate("Password1!")), ErrPasswordCommon)
+}
+
+func TestCommonPasswordListIsSorted(t *testing.T) {
+	list := loadCommonPasswords()
+	require.NotEmpty(t, list)
+	assert.True(t, sort.StringsAreSorted(list), "containsSorted는 정렬된 목록이 필요합니다")
+}
+
+func TestPasswordPolicy_UserInfo(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	const email, username = "Jane.Doe@example.com", "janed"
+
+	cases := []struct {
+		password string
+		contains bool
+	}{
+		{"jane.doe@example.com-X1!", true}, // 이메일 전체
+		{"X1!JANE.DOEzz", true},            // 이메일 아이디, 대소문자 무시
+		{"Zz9#janedZz", true},              // 사용자 이름
+		{"Zz9#Unrelated", false},
+	}
+	for _, tc := range cases {
+		violations := policyViolations(t, policy.Validate(tc.password, email, username))
+		if tc.contains {
+			assert.Contains(t, violations, ErrPasswordContainsUserInfo, tc.password)
+		} else {
+			assert.NotContains(t, violations, ErrPasswordContainsUserInfo, tc.password)
+		}
+	}
+
+	// 짧은 사용자 정보는 우연히 겹칠 수 있어서 검사하지 않음
+	assert.NoError(t, policy.Validate("Zz9#bobcatZz", "bo@example.com", "bo"))
+
+	policy.ForbidUserInfo = false
+	assert.NotContains(t, policyViolations(t, policy.Validate("Zz9#janedZz", email, username)), ErrPasswordContainsUserInfo)
+}
+
+func TestPasswordPolicy_CollectsAllViolations(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	err := policy.Validate("abc")
+	violations := policyViolations(t, err)
+	assert.Equal(t, []error{ErrPasswordTooShort, ErrPasswordNoUpper, ErrPasswordNoDigit, ErrPasswordNoSpecial}, violations)
+	assert.ErrorIs(t, err, ErrPasswordNoDigit)
+
+	var policyErr *PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, "8자 이상이어야 합니다", policyErr.Messages(policy)[0])
+
+	assert.NoError(t, policy.Validate("Corr3ct-Horse-Battery"))
+}
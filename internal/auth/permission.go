@@ -0,0 +1,12 @@
+package auth
+
+import (
+	"context"
+	"gorm-test/internal/domain"
+)
+
+// PermissionChecker는 역할에 권한이 있는지 확인합니다.
+// 역할과 권한은 DB에 저장되므로 서비스 레이어에서 구현합니다. (RequirePermission, 서비스의 권한 검사에 사용)
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role string, permission domain.Permission) (bool, error)
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// PersonalTokenPrefix는 Personal Access Token의 접두사입니다.
+// JWT와 구분하고, 유출된 토큰을 비밀 탐지 도구가 찾을 수 있게 합니다.
+const PersonalTokenPrefix = "gbp_"
+
+// Personal Access Token에 부여할 수 있는 scope
+const (
+	ScopePostsRead     = "posts:read"
+	ScopePostsWrite    = "posts:write"
+	ScopeCommentsWrite = "comments:write"
+	ScopeAdmin         = "admin"
+)
+
+var (
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+var validScopes = map[string]bool{
+	ScopePostsRead:     true,
+	ScopePostsWrite:    true,
+	ScopeCommentsWrite: true,
+	ScopeAdmin:         true,
+}
+
+// ValidateScopes는 scope 목록을 검증합니다. 비어 있거나 모르는 scope가 있으면 ErrInvalidScope입니다.
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !validScopes[scope] {
+			return ErrInvalidScope
+		}
+	}
+	return nil
+}
+
+// PersonalTokenVerifier는 Personal Access Token을 확인하고 사용자 정보를 반환합니다.
+// 토큰은 DB에 저장되므로 구현은 서비스 계층에 있습니다.
+type PersonalTokenVerifier interface {
+	VerifyPersonalToken(ctx context.Context, token string) (*CustomClaims, error)
+}
+
+// SetPersonalTokenVerifier는 Personal Access Token 검증기를 등록합니다.
+func (s *TokenService) SetPersonalTokenVerifier(verifier PersonalTokenVerifier) {
+	s.personalTokens = verifier
+}
+
+// ValidatePersonalToken은 Personal Access Token을 검증합니다.
+// 없거나 폐기된 토큰은 ErrInvalidToken, 만료된 토큰은 ErrExpiredToken입니다.
+func (s *TokenService) ValidatePersonalToken(ctx context.Context, token string) (*CustomClaims, error) {
+	if s.personalTokens == nil {
+		return nil, ErrInvalidToken
+	}
+	return s.personalTokens.VerifyPersonalToken(ctx, token)
+}
+
+// IsPersonalToken은 Personal Access Token 형식인지 확인합니다.
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
+
+// GeneratePersonalToken은 새 Personal Access Token 원문을 생성합니다.
+func GeneratePersonalToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashPersonalToken은 저장/조회용 SHA-256 해시를 반환합니다.
+func HashPersonalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRotated  = errors.New("session token already rotated")
+)
+
+// Session은 기기(로그인)별 세션입니다.
+//
+//	로그인할 때마다 새 세션이 만들어지고, 세션마다 Refresh Token이 따로 발급됩니다.
+//	휴대폰에서 로그인해도 노트북의 세션은 그대로 유지됩니다.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uint      `json:"user_id"`
+	TokenID    string    `json:"token_id"` // 현재 유효한 Refresh Token의 jti (Rotation 시 교체)
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	TwoFactor  bool      `json:"two_factor"` // 2단계 인증을 거쳐 만든 세션
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// SessionMeta는 세션 생성 시 기록하는 기기 정보입니다.
+type SessionMeta struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+	TwoFactor  bool
+}
+
+// RefreshClaims는 Refresh Token의 페이로드입니다.
+type RefreshClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// ListSessions는 사용자의 활성 세션 목록을 반환합니다.
+func (s *TokenService) ListSessions(ctx context.Context, userID uint) ([]*Session, error) {
+	if s.tokenStore == nil {
+		return []*Session{}, nil
+	}
+	return s.tokenStore.ListSessions(ctx, userID)
+}
+
+// GetSession은 사용자의 세션을 조회합니다.
+// 다른 사용자의 세션이면 ErrSessionNotFound를 반환합니다.
+func (s *TokenService) GetSession(ctx context.Context, userID uint, sessionID string) (*Session, error) {
+	if s.tokenStore == nil || sessionID == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	session, err := s.tokenStore.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// RevokeSession은 사용자의 세션 하나를 종료합니다.
+// 다른 사용자의 세션이면 ErrSessionNotFound를 반환합니다.
+func (s *TokenService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	if s.tokenStore == nil {
+		return nil
+	}
+
+	session, err := s.tokenStore.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, session)
+}
+
+// RevokeOtherSessions는 keepSessionID를 제외한 사용자의 모든 세션을 종료합니다.
+// 종료한 세션 수를 반환합니다.
+func (s *TokenService) RevokeOtherSessions(ctx context.Context, userID uint, keepSessionID string) (int, error) {
+	if s.tokenStore == nil {
+		return 0, nil
+	}
+
+	sessions, err := s.tokenStore.ListSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := s.revokeSession(ctx, session); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// RevokeAllSessions는 사용자의 모든 세션을 종료합니다. (비밀번호 변경, 계정 탈취 의심 등)
+func (s *TokenService) RevokeAllSessions(ctx context.Context, userID uint) error {
+	if s.tokenStore == nil {
+		return nil
+	}
+
+	sessions, err := s.tokenStore.ListSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := s.blacklistSession(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.tokenStore.DeleteAllSessions(ctx, userID)
+}
+
+// revokeSession은 세션을 삭제하고, 세션에서 발급된 Access Token도 즉시 무효화합니다.
+func (s *TokenService) revokeSession(ctx context.Context, session *Session) error {
+	if err := s.blacklistSession(ctx, session.ID); err != nil {
+		return err
+	}
+	return s.tokenStore.DeleteSession(ctx, session.UserID, session.ID)
+}
+
+// blacklistSession은 세션에서 발급된 Access Token을 블랙리스트에 추가합니다.
+// Access Token의 최대 수명 동안만 유지하면 됩니다.
+func (s *TokenService) blacklistSession(ctx context.Context, sessionID string) error {
+	return s.tokenStore.AddToBlacklist(ctx, sessionBlacklistID(sessionID), s.accessExpiry)
+}
+
+func sessionBlacklistID(sessionID string) string {
+	return "session:" + sessionID
+}
+
+// IsRevoked는 Access Token이 무효화되었는지 확인합니다.
+// 토큰 자체(로그아웃) 또는 토큰을 발급한 세션이 블랙리스트에 있으면 true입니다.
+func (s *TokenService) IsRevoked(ctx context.Context, claims *CustomClaims, tokenString string) (bool, error) {
+	if s.tokenStore == nil {
+		return false, nil
+	}
+
+	tokenID := claims.RegisteredClaims.ID
+	if tokenID == "" {
+		tokenID = hashToken(tokenString)
+	}
+
+	blacklisted, err := s.tokenStore.IsBlacklisted(ctx, tokenID)
+	if err != nil || blacklisted {
+		return blacklisted, err
+	}
+
+	if claims.SessionID == "" {
+		return false, nil
+	}
+	return s.tokenStore.IsBlacklisted(ctx, sessionBlacklistID(claims.SessionID))
+}
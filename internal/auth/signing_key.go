@@ -0,0 +1,331 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 서명 알고리즘
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrSigningKeyNotFound   = errors.New("signing key not found")
+)
+
+// 모르는 kid가 왔을 때 저장소를 다시 읽는 최소 간격 (다른 인스턴스가 교체한 키 반영)
+const keyReloadInterval = 10 * time.Second
+
+// SigningKey는 JWT 서명 키입니다. 토큰 헤더의 kid로 식별합니다.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	CreatedAt time.Time
+}
+
+// Public은 검증용 공개키를 반환합니다.
+func (k *SigningKey) Public() crypto.PublicKey {
+	return k.Private.Public()
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Algorithm == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// GenerateSigningKey는 새 서명 키를 생성합니다.
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var signer crypto.Signer
+	switch algorithm {
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	now := time.Now()
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	return &SigningKey{
+		ID:        now.UTC().Format("20060102") + "-" + hex.EncodeToString(b),
+		Algorithm: algorithm,
+		Private:   signer,
+		CreatedAt: now,
+	}, nil
+}
+
+// algorithmOf는 개인키 타입에 맞는 서명 알고리즘을 반환합니다.
+func algorithmOf(key crypto.Signer) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return AlgRS256, nil
+	case ed25519.PrivateKey:
+		return AlgEdDSA, nil
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
+
+// KeyManagerConfig는 서명 키 교체 설정입니다.
+type KeyManagerConfig struct {
+	Algorithm        string        // 새 키 알고리즘 (RS256, EdDSA)
+	RotationInterval time.Duration // 서명 키 교체 주기
+	GracePeriod      time.Duration // 교체된 키로 서명된 토큰을 계속 검증하는 기간 (가장 긴 토큰 수명 이상)
+}
+
+// KeyManager는 서명 키 목록을 관리합니다.
+//
+//	가장 최근 키로 서명하고, 교체된 이전 키는 유예 기간 동안 검증에만 사용합니다.
+//	키는 KeyStore에 저장되므로 같은 저장소를 쓰는 인스턴스끼리 키를 공유합니다.
+type KeyManager struct {
+	store KeyStore
+	cfg   KeyManagerConfig
+
+	mu       sync.RWMutex
+	keys     []*SigningKey // 최신순
+	loadedAt time.Time
+}
+
+// NewKeyManager는 저장소에서 키를 읽고, 키가 없거나 교체 주기가 지났으면 새 키를 만듭니다.
+func NewKeyManager(ctx context.Context, store KeyStore, cfg KeyManagerConfig) (*KeyManager, error) {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgRS256
+	}
+	if cfg.Algorithm != AlgRS256 && cfg.Algorithm != AlgEdDSA {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, cfg.Algorithm)
+	}
+	if cfg.RotationInterval <= 0 {
+		cfg.RotationInterval = 30 * 24 * time.Hour
+	}
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = 7 * 24 * time.Hour
+	}
+
+	m := &KeyManager{store: store, cfg: cfg}
+	if err := m.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Refresh는 저장소의 키를 다시 읽고 교체 주기가 지났으면 새 키를 만듭니다.
+// 유예 기간이 지난 키는 저장소에서 삭제합니다.
+func (m *KeyManager) Refresh(ctx context.Context) error {
+	keys, err := m.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	sortKeys(keys)
+
+	now := time.Now()
+	if len(keys) == 0 || now.Sub(keys[0].CreatedAt) >= m.cfg.RotationInterval {
+		key, err := GenerateSigningKey(m.cfg.Algorithm)
+		if err != nil {
+			return err
+		}
+		if err := m.store.Save(ctx, key); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "jwt signing key rotated", "kid", key.ID, "alg", key.Algorithm)
+		keys = append([]*SigningKey{key}, keys...)
+	}
+
+	active, expired := m.partition(keys, now)
+	for _, key := range expired {
+		if err := m.store.Delete(ctx, key.ID); err != nil {
+			slog.WarnContext(ctx, "failed to delete expired jwt signing key", "kid", key.ID, "error", err)
+		}
+	}
+
+	m.set(active)
+	return nil
+}
+
+// Rotate는 교체 주기와 관계없이 즉시 새 서명 키로 교체합니다. (키 유출 의심 등)
+// 이전 키는 유예 기간 동안 검증에 계속 사용됩니다.
+func (m *KeyManager) Rotate(ctx context.Context) (*SigningKey, error) {
+	key, err := GenerateSigningKey(m.cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	keys := append([]*SigningKey{key}, m.keys...)
+	m.mu.RUnlock()
+
+	active, _ := m.partition(keys, time.Now())
+	m.set(active)
+	return key, nil
+}
+
+// Run은 interval마다 Refresh를 실행합니다. ctx가 끝나면 종료합니다.
+func (m *KeyManager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil {
+				slog.ErrorContext(ctx, "jwt signing key refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+// SigningKey는 현재 서명에 사용하는 키를 반환합니다.
+func (m *KeyManager) SigningKey() *SigningKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.keys[0]
+}
+
+// Key는 kid에 해당하는 검증 키를 반환합니다.
+// 모르는 kid면 다른 인스턴스가 키를 교체했을 수 있으므로 저장소를 다시 읽습니다.
+func (m *KeyManager) Key(ctx context.Context, kid string) (*SigningKey, error) {
+	if key, ok := m.lookup(kid); ok {
+		return key, nil
+	}
+
+	m.mu.RLock()
+	recent := time.Since(m.loadedAt) < keyReloadInterval
+	m.mu.RUnlock()
+	if recent {
+		return nil, ErrSigningKeyNotFound
+	}
+
+	keys, err := m.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortKeys(keys)
+	if len(keys) > 0 {
+		active, _ := m.partition(keys, time.Now())
+		m.set(active)
+	}
+
+	if key, ok := m.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrSigningKeyNotFound
+}
+
+// JWKS는 검증에 사용하는 공개키 목록을 JWK Set 형식으로 반환합니다.
+func (m *KeyManager) JWKS() JWKSet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(m.keys))}
+	for _, key := range m.keys {
+		set.Keys = append(set.Keys, key.jwk())
+	}
+	return set
+}
+
+func (m *KeyManager) lookup(kid string) (*SigningKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.keys {
+		if key.ID == kid {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (m *KeyManager) set(keys []*SigningKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = keys
+	m.loadedAt = time.Now()
+}
+
+// partition은 최신순 키 목록을 검증에 쓸 키와 유예 기간이 지난 키로 나눕니다.
+// 키는 다음(더 최신) 키가 만들어진 시점부터 유예 기간이 지나면 만료됩니다.
+func (m *KeyManager) partition(keys []*SigningKey, now time.Time) (active, expired []*SigningKey) {
+	for i, key := range keys {
+		if i > 0 && now.Sub(keys[i-1].CreatedAt) > m.cfg.GracePeriod {
+			return active, keys[i:]
+		}
+		active = append(active, key)
+	}
+	return active, nil
+}
+
+func sortKeys(keys []*SigningKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+}
+
+// JWK는 JSON Web Key (RFC 7517) 공개키입니다.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 (RFC 8037)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet은 /.well-known/jwks.json 응답입니다.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k *SigningKey) jwk() JWK {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+
+	switch pub := k.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
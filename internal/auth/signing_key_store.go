@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyStore는 서명 키 저장소입니다.
+type KeyStore interface {
+	Load(ctx context.Context) ([]*SigningKey, error)
+	Save(ctx context.Context, key *SigningKey) error
+	Delete(ctx context.Context, kid string) error
+}
+
+// pem 헤더 이름
+const (
+	pemHeaderKeyID     = "Key-Id"
+	pemHeaderCreatedAt = "Created-At"
+)
+
+// FileKeyStore는 서명 키를 디렉터리에 PEM(PKCS#8) 파일로 저장합니다.
+//
+//	파일 이름은 {kid}.pem이고, 생성 시각은 PEM 헤더에 기록합니다.
+//	여러 인스턴스가 키를 공유하려면 같은 디렉터리(공유 볼륨, Secret 마운트 등)를 사용합니다.
+type FileKeyStore struct {
+	dir string
+}
+
+// NewFileKeyStore 생성자
+func NewFileKeyStore(dir string) *FileKeyStore {
+	return &FileKeyStore{dir: dir}
+}
+
+func (s *FileKeyStore) Load(ctx context.Context) ([]*SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*SigningKey, 0, len(paths))
+	for _, path := range paths {
+		key, err := readKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("서명 키 읽기 실패 (%s): %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *FileKeyStore) Save(ctx context.Context, key *SigningKey) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key.Private)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{
+		Type: "PRIVATE KEY",
+		Headers: map[string]string{
+			pemHeaderKeyID:     key.ID,
+			pemHeaderCreatedAt: key.CreatedAt.UTC().Format(time.RFC3339),
+		},
+		Bytes: der,
+	})
+
+	// 다른 인스턴스가 쓰는 도중의 파일을 읽지 않도록 임시 파일에 쓴 뒤 이름 변경
+	tmp, err := os.CreateTemp(s.dir, ".key-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.keyPath(key.ID))
+}
+
+func (s *FileKeyStore) Delete(ctx context.Context, kid string) error {
+	err := os.Remove(s.keyPath(kid))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileKeyStore) keyPath(kid string) string {
+	return filepath.Join(s.dir, filepath.Base(kid)+".pem")
+}
+
+func readKeyFile(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("PKCS#8 PEM 형식이 아닙니다")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	algorithm, err := algorithmOf(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	kid := block.Headers[pemHeaderKeyID]
+	if kid == "" {
+		kid = strings.TrimSuffix(filepath.Base(path), ".pem")
+	}
+
+	// 헤더가 없는 키(직접 넣은 키)는 파일 수정 시각 사용
+	createdAt, err := time.Parse(time.RFC3339, block.Headers[pemHeaderCreatedAt])
+	if err != nil {
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			return nil, statErr
+		}
+		createdAt = info.ModTime()
+	}
+
+	return &SigningKey{
+		ID:        kid,
+		Algorithm: algorithm,
+		Private:   signer,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// MemoryKeyStore는 메모리에 키를 보관합니다.
+// 단일 인스턴스 개발용 - 재시작하면 키가 바뀌어 발급된 토큰이 모두 무효화됩니다.
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	keys map[string]*SigningKey
+}
+
+// NewMemoryKeyStore 생성자
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: make(map[string]*SigningKey)}
+}
+
+func (s *MemoryKeyStore) Load(ctx context.Context) ([]*SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]*SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *MemoryKeyStore) Save(ctx context.Context, key *SigningKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *MemoryKeyStore) Delete(ctx context.Context, kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, kid)
+	return nil
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAgedKey는 createdAt 시각에 만든 것처럼 보이는 키를 생성합니다.
+func newAgedKey(t *testing.T, id string, createdAt time.Time) *SigningKey {
+	t.Helper()
+	key, err := GenerateSigningKey(AlgEdDSA)
+	require.NoError(t, err)
+	key.ID = id
+	key.CreatedAt = createdAt
+	return key
+}
+
+func storeKeyIDs(t *testing.T, store KeyStore) []string {
+	t.Helper()
+	keys, err := store.Load(context.Background())
+	require.NoError(t, err)
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
+
+func jwksKeyIDs(m *KeyManager) []string {
+	var ids []string
+	for _, key := range m.JWKS().Keys {
+		ids = append(ids, key.Kid)
+	}
+	return ids
+}
+
+func TestKeyManager_RotatesAfterInterval(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+	old := newAgedKey(t, "old", time.Now().Add(-31*24*time.Hour))
+	require.NoError(t, store.Save(ctx, old))
+
+	m, err := NewKeyManager(ctx, store, KeyManagerConfig{
+		Algorithm:        AlgEdDSA,
+		RotationInterval: 30 * 24 * time.Hour,
+		GracePeriod:      7 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	// 새 키로 서명하고, 이전 키는 새 키가 만들어진 시점부터 유예 기간 동안 검증에 사용
+	current := m.SigningKey()
+	assert.NotEqual(t, "old", current.ID)
+	assert.Equal(t, []string{current.ID, "old"}, jwksKeyIDs(m))
+	assert.ElementsMatch(t, []string{current.ID, "old"}, storeKeyIDs(t, store))
+}
+
+func TestKeyManager_KeepsCurrentKeyWithinInterval(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+	require.NoError(t, store.Save(ctx, newAgedKey(t, "current", time.Now().Add(-time.Hour))))
+
+	m, err := NewKeyManager(ctx, store, KeyManagerConfig{Algorithm: AlgEdDSA, RotationInterval: 24 * time.Hour})
+	require.NoError(t, err)
+
+	assert.Equal(t, "current", m.SigningKey().ID)
+	assert.Len(t, storeKeyIDs(t, store), 1)
+}
+
+func TestKeyManager_PartitionExpiresKeysPastGrace(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryKeyStore()
+	for _, key := range []*SigningKey{
+		newAgedKey(t, "k3", now.Add(-time.Hour)),
+		newAgedKey(t, "k2", now.Add(-10*24*time.Hour)), // k3가 만들어진 지 1시간 - 유예 중
+		newAgedKey(t, "k1", now.Add(-20*24*time.Hour)), // k2가 만들어진 지 10일 - 유예 기간(7일) 지남
+	} {
+		require.NoError(t, store.Save(ctx, key))
+	}
+
+	m, err := NewKeyManager(ctx, store, KeyManagerConfig{
+		Algorithm:        AlgEdDSA,
+		RotationInterval: 30 * 24 * time.Hour,
+		GracePeriod:      7 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "k3", m.SigningKey().ID)
+	assert.Equal(t, []string{"k3", "k2"}, jwksKeyIDs(m))
+
+	// 만료된 키는 저장소에서도 삭제
+	assert.ElementsMatch(t, []string{"k3", "k2"}, storeKeyIDs(t, store))
+	_, err = m.Key(ctx, "k1")
+	assert.ErrorIs(t, err, ErrSigningKeyNotFound)
+}
+
+func TestKeyManager_RotateKeepsPreviousKeyForVerification(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewKeyManager(ctx, NewMemoryKeyStore(), KeyManagerConfig{Algorithm: AlgEdDSA})
+	require.NoError(t, err)
+	previous := m.SigningKey()
+
+	rotated, err := m.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.ID, m.SigningKey().ID)
+
+	key, err := m.Key(ctx, previous.ID)
+	require.NoError(t, err)
+	assert.Equal(t, previous.ID, key.ID)
+}
+
+func TestKeyManager_KeyLookupReloadsUnknownKid(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+	cfg := KeyManagerConfig{Algorithm: AlgEdDSA}
+
+	m1, err := NewKeyManager(ctx, store, cfg)
+	require.NoError(t, err)
+	m2, err := NewKeyManager(ctx, store, cfg)
+	require.NoError(t, err)
+
+	// 다른 인스턴스가 키를 교체
+	rotated, err := m2.Rotate(ctx)
+	require.NoError(t, err)
+
+	// 최근에 읽었으면 저장소를 다시 읽지 않음 (모르는 kid로 저장소를 두드리지 못하도록)
+	_, err = m1.Key(ctx, rotated.ID)
+	assert.ErrorIs(t, err, ErrSigningKeyNotFound)
+
+	m1.mu.Lock()
+	m1.loadedAt = time.Now().Add(-keyReloadInterval)
+	m1.mu.Unlock()
+
+	key, err := m1.Key(ctx, rotated.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.ID, key.ID)
+
+	_, err = m1.Key(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrSigningKeyNotFound)
+}
+
+func TestKeyFunc_RejectsAlgorithmMismatch(t *testing.T) {
+	tokens, _ := newTestTokenService(t)
+	key := tokens.keys.SigningKey()
+	require.Equal(t, AlgEdDSA, key.Algorithm)
+
+	// 같은 kid라도 키와 다른 알고리즘이면 거부
+	_, err := tokens.keyFunc(&jwt.Token{Method: jwt.SigningMethodRS256, Header: map[string]any{"kid": key.ID}})
+	assert.Error(t, err)
+
+	public, err := tokens.keyFunc(&jwt.Token{Method: jwt.SigningMethodEdDSA, Header: map[string]any{"kid": key.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), public)
+
+	_, err = tokens.keyFunc(&jwt.Token{Method: jwt.SigningMethodEdDSA, Header: map[string]any{"kid": "unknown"}})
+	assert.ErrorIs(t, err, ErrSigningKeyNotFound)
+}
+
+func TestFileKeyStore_AlgorithmFollowsKeyType(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileKeyStore(t.TempDir())
+
+	for _, alg := range []string{AlgRS256, AlgEdDSA} {
+		key, err := GenerateSigningKey(alg)
+		require.NoError(t, err)
+		key.ID = alg
+		require.NoError(t, store.Save(ctx, key))
+	}
+
+	keys, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		assert.Equal(t, key.ID, key.Algorithm) // 파일에는 알고리즘이 없고 개인키 타입으로 결정
+	}
+
+	_, err = GenerateSigningKey("HS256")
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
@@ -14,23 +14,48 @@ import (
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrExpiredToken       = errors.New("token has expired")
+	ErrRefreshTokenReused = errors.New("refresh token reused")
 )
 
+// TokenReuseError는 이미 교체된 Refresh Token이 다시 제출되었을 때 반환됩니다.
+// 해당 세션(토큰 패밀리)은 이미 폐기된 상태입니다.
+type TokenReuseError struct {
+	UserID    uint
+	SessionID string
+}
+
+func (e *TokenReuseError) Error() string {
+	return fmt.Sprintf("refresh token reused: user=%d session=%s", e.UserID, e.SessionID)
+}
+
+func (e *TokenReuseError) Is(target error) bool {
+	return target == ErrRefreshTokenReused
+}
+
+// audience는 Access Token의 aud 클레임입니다.
+const audience = "api.example.com"
+
+// 허용하는 서명 알고리즘
+var validMethods = []string{AlgRS256, AlgEdDSA}
+
 // TokenService는 JWT 토큰 생성과 검증을 담당합니다.
+// 토큰은 KeyManager의 현재 키로 서명하고, 헤더의 kid로 검증 키를 찾습니다.
 type TokenService struct {
-	secretKey     []byte
+	keys          *KeyManager
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 	issuer        string
 	tokenStore    TokenStore
+
+	personalTokens PersonalTokenVerifier
 }
 
 // NewTokenService는 TokenService를 생성합니다.
-func NewTokenService(secretKey string, accessExpiry, refreshExpiry time.Duration, tokenStore TokenStore) *TokenService {
+func NewTokenService(keys *KeyManager, accessExpiry, refreshExpiry time.Duration, tokenStore TokenStore) *TokenService {
 	return &TokenService{
-		secretKey:     []byte(secretKey),
+		keys:          keys,
 		accessExpiry:  accessExpiry,
 		refreshExpiry: refreshExpiry,
 		issuer:        "goboard-api",
@@ -38,19 +63,33 @@ func NewTokenService(secretKey string, accessExpiry, refreshExpiry time.Duration
 	}
 }
 
+// TokenSubject는 Access Token에 담을 사용자 정보입니다.
+type TokenSubject struct {
+	UserID        uint
+	Email         string
+	Username      string
+	Role          string
+	EmailVerified bool
+}
+
 // GenerateAccessToken은 액세스 토큰을 생성합니다.
-func (s *TokenService) GenerateAccessToken(userID uint, email, username, role string) (string, error) {
+// session은 토큰을 발급한 세션으로, 현재 기기 식별과 2단계 인증 여부에 사용됩니다.
+func (s *TokenService) GenerateAccessToken(subject TokenSubject, session *Session) (string, error) {
 	now := time.Now()
 	tokenID := generateTokenID()
 
 	claims := CustomClaims{
-		UserID:   userID,
-		Email:    email,
-		Username: username,
-		Role:     role,
+		UserID:        subject.UserID,
+		Email:         subject.Email,
+		Username:      subject.Username,
+		Role:          subject.Role,
+		EmailVerified: subject.EmailVerified,
+		SessionID:     session.ID,
+		TwoFactor:     session.TwoFactor,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    s.issuer,
-			Subject:   fmt.Sprintf("%d", userID),
+			Subject:   fmt.Sprintf("%d", subject.UserID),
+			Audience:  jwt.ClaimStrings{audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -58,8 +97,7 @@ func (s *TokenService) GenerateAccessToken(userID uint, email, username, role st
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	return s.sign(claims)
 }
 
 // ValidateToken은 토큰을 검증하고 클레임을 반환합니다.
@@ -69,18 +107,12 @@ func (s *TokenService) ValidateToken(tokenString string) (*CustomClaims, error)
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&CustomClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			// 알고리즘 검증
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return s.secretKey, nil
-		},
-		jwt.WithValidMethods([]string{"HS256"}), // 허용하는 서명 알고리즘
-		jwt.WithIssuer("goboard-api"),           // issuer 검증
-		jwt.WithAudience("api.example.com"),     // audience 검증
-		jwt.WithExpirationRequired(),            // exp 클레임 필수
-		jwt.WithLeeway(5*time.Second),           // 시간 오차 허용 (서버 간 시간 차이 대응 - 방금 발급된 토큰이 "아직 유효하지 않음"으로 거부되는 것을 방지)
+		s.keyFunc,
+		jwt.WithValidMethods(validMethods), // 허용하는 서명 알고리즘
+		jwt.WithIssuer("goboard-api"),      // issuer 검증
+		jwt.WithAudience(audience),         // audience 검증
+		jwt.WithExpirationRequired(),       // exp 클레임 필수
+		jwt.WithLeeway(5*time.Second),      // 시간 오차 허용 (서버 간 시간 차이 대응 - 방금 발급된 토큰이 "아직 유효하지 않음"으로 거부되는 것을 방지)
 	)
 
 	if err != nil {
@@ -95,6 +127,34 @@ func (s *TokenService) ValidateToken(tokenString string) (*CustomClaims, error)
 	return claims, nil
 }
 
+// sign은 현재 서명 키로 토큰을 서명합니다.
+func (s *TokenService) sign(claims jwt.Claims) (string, error) {
+	key := s.keys.SigningKey()
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// keyFunc는 토큰 헤더의 kid로 검증 키를 찾습니다.
+func (s *TokenService) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.keys.Key(context.Background(), kid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 알고리즘 검증 - 키와 다른 알고리즘으로 서명된 토큰 거부
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public(), nil
+}
+
+// JWKS는 토큰 검증용 공개키 목록을 반환합니다.
+func (s *TokenService) JWKS() JWKSet {
+	return s.keys.JWKS()
+}
+
 // 토큰 에러 제어
 func (s *TokenService) handleTokenError(err error) error {
 	switch {
@@ -119,29 +179,76 @@ func (s *TokenService) handleTokenError(err error) error {
 	}
 }
 
-// GenerateRefreshToken은 리프레시 토큰을 생성합니다.
-func (s *TokenService) GenerateRefreshToken(ctx context.Context, userID uint) (string, error) {
+// CreateSession은 새 세션을 만들고 세션의 리프레시 토큰을 발급합니다.
+func (s *TokenService) CreateSession(ctx context.Context, userID uint, meta SessionMeta) (string, *Session, error) {
 	now := time.Now()
-	tokenID := generateTokenID()
+	session := &Session{
+		ID:         generateTokenID(),
+		UserID:     userID,
+		DeviceName: meta.DeviceName,
+		UserAgent:  meta.UserAgent,
+		IP:         meta.IP,
+		TwoFactor:  meta.TwoFactor,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
 
-	claims := jwt.RegisteredClaims{
-		Issuer:    s.issuer,
-		Subject:   fmt.Sprintf("%d", userID),
-		ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshExpiry)),
-		IssuedAt:  jwt.NewNumericDate(now),
-		NotBefore: jwt.NewNumericDate(now),
-		ID:        generateTokenID(), // 고유 ID
+	tokenString, err := s.issueRefreshToken(ctx, session)
+	if err != nil {
+		return "", nil, err
 	}
+	return tokenString, session, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.secretKey)
+// RotateRefreshToken은 세션의 리프레시 토큰을 새로 발급합니다. (Token Rotation)
+// 이전 리프레시 토큰은 더 이상 사용할 수 없습니다.
+//
+//	세션은 검증한 토큰이 아직 최신일 때만 교체됩니다. (compare-and-swap)
+//	같은 토큰으로 동시에 갱신하면 한 요청만 성공하고, 나머지는 재사용으로 보고 세션을 폐기합니다.
+func (s *TokenService) RotateRefreshToken(ctx context.Context, session *Session) (string, error) {
+	prevTokenID := session.TokenID
+	session.LastUsedAt = time.Now()
+	session.TokenID = generateTokenID()
+
+	tokenString, err := s.signRefreshToken(session)
+	if err != nil {
+		return "", err
+	}
+
+	if s.tokenStore == nil {
+		return tokenString, nil
+	}
+
+	if err := s.tokenStore.SwapSession(ctx, session, prevTokenID, s.refreshExpiry); err != nil {
+		switch {
+		case errors.Is(err, ErrSessionRotated):
+			// 검증 이후 다른 요청이 먼저 교체함 - 패밀리가 갈라지지 않도록 전체 폐기
+			if err := s.revokeSession(ctx, session); err != nil {
+				return "", err
+			}
+			return "", &TokenReuseError{UserID: session.UserID, SessionID: session.ID}
+		case errors.Is(err, ErrSessionNotFound):
+			// 그 사이 로그아웃됨
+			return "", ErrInvalidToken
+		}
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// issueRefreshToken은 새 jti로 리프레시 토큰을 서명하고 세션을 저장합니다.
+func (s *TokenService) issueRefreshToken(ctx context.Context, session *Session) (string, error) {
+	session.TokenID = generateTokenID()
+
+	tokenString, err := s.signRefreshToken(session)
 	if err != nil {
 		return "", err
 	}
 
 	// Redis에 저장
 	if s.tokenStore != nil {
-		if err := s.tokenStore.StoreRefreshToken(ctx, userID, tokenID, s.refreshExpiry); err != nil {
+		if err := s.tokenStore.SaveSession(ctx, session, s.refreshExpiry); err != nil {
 			return "", err
 		}
 	}
@@ -149,6 +256,24 @@ func (s *TokenService) GenerateRefreshToken(ctx context.Context, userID uint) (s
 	return tokenString, nil
 }
 
+// signRefreshToken은 세션의 현재 jti로 리프레시 토큰을 서명합니다.
+func (s *TokenService) signRefreshToken(session *Session) (string, error) {
+	now := time.Now()
+	claims := RefreshClaims{
+		SessionID: session.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   fmt.Sprintf("%d", session.UserID),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        session.TokenID, // 고유 ID
+		},
+	}
+
+	return s.sign(claims)
+}
+
 // generateTokenID는 토큰 고유 ID를 생성합니다.
 func generateTokenID() string {
 	b := make([]byte, 16)
@@ -161,49 +286,89 @@ func (s *TokenService) GetAccessExpiry() int64 {
 	return int64(s.accessExpiry.Seconds())
 }
 
-// ValidateRefreshToken은 리프레시 토큰을 검증합니다.
-func (s *TokenService) ValidateRefreshToken(ctx context.Context, tokenString string) (uint, error) {
+// ValidateRefreshToken은 리프레시 토큰을 검증하고 토큰이 속한 세션을 반환합니다.
+//
+//	세션이 삭제(로그아웃)되었으면 ErrInvalidToken을 반환합니다.
+//	세션은 Rotation으로 이어지는 토큰 패밀리입니다. 이미 교체된 토큰이 제출되면
+//	세션을 폐기하고 *TokenReuseError (errors.Is(err, ErrRefreshTokenReused))를 반환합니다.
+func (s *TokenService) ValidateRefreshToken(ctx context.Context, tokenString string) (*Session, error) {
+	claims, userID, err := s.parseRefreshToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// 저장소가 없으면 토큰 정보만으로 세션 구성
+	if s.tokenStore == nil {
+		return &Session{ID: claims.SessionID, UserID: userID, TokenID: claims.ID}, nil
+	}
+
+	// Redis에서 세션 확인
+	session, err := s.findRefreshSession(ctx, claims, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.TokenID != claims.ID {
+		// 이미 교체된 토큰이 다시 사용됨 - 탈취 의심
+		// 정상 사용자와 공격자 중 누가 최신 토큰을 갖고 있는지 알 수 없으므로 패밀리 전체를 폐기
+		if err := s.revokeSession(ctx, session); err != nil {
+			return nil, err
+		}
+		return nil, &TokenReuseError{UserID: session.UserID, SessionID: session.ID}
+	}
+
+	return session, nil
+}
+
+// parseRefreshToken은 리프레시 토큰의 서명과 클레임을 검증하고 사용자 ID를 반환합니다.
+func (s *TokenService) parseRefreshToken(tokenString string) (*RefreshClaims, uint, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
-		&jwt.RegisteredClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return s.secretKey, nil
-		},
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		&RefreshClaims{},
+		s.keyFunc,
+		jwt.WithValidMethods(validMethods),
 		jwt.WithIssuer(s.issuer),
 		jwt.WithExpirationRequired(),
 	)
 
 	if err != nil {
-		return 0, s.handleTokenError(err)
+		return nil, 0, s.handleTokenError(err)
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
-	if !ok || !token.Valid {
-		return 0, ErrInvalidToken
+	claims, ok := token.Claims.(*RefreshClaims)
+	if !ok || !token.Valid || claims.SessionID == "" {
+		return nil, 0, ErrInvalidToken
+	}
+
+	// Access Token/2단계 인증 대기 토큰은 aud가 있음 - Refresh Token으로 받지 않음
+	if len(claims.Audience) > 0 {
+		return nil, 0, ErrInvalidToken
 	}
 
 	// Subject에서 UserID 추출
 	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
 	if err != nil {
-		return 0, ErrInvalidToken
+		return nil, 0, ErrInvalidToken
 	}
 
-	// Redis에서 유효성 확인
-	if s.tokenStore != nil {
-		valid, err := s.tokenStore.IsRefreshTokenValid(ctx, uint(userID), claims.ID)
-		if err != nil {
-			return 0, err
-		}
-		if !valid {
-			// 이미 사용된 토큰 (Token Rotation으로 교체됨)
-			return 0, ErrInvalidToken
+	return claims, uint(userID), nil
+}
+
+// findRefreshSession은 리프레시 토큰이 속한 세션을 조회합니다.
+// 로그아웃되었거나 만료된 세션, 다른 사용자의 세션이면 ErrInvalidToken입니다.
+func (s *TokenService) findRefreshSession(ctx context.Context, claims *RefreshClaims, userID uint) (*Session, error) {
+	session, err := s.tokenStore.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, ErrInvalidToken
 		}
+		return nil, err
+	}
+
+	if session.UserID != userID {
+		return nil, ErrInvalidToken
 	}
-	return uint(userID), nil
+	return session, nil
 }
 
 /*
@@ -224,18 +389,17 @@ func (s *TokenService) RevokeAccessToken(ctx context.Context, tokenString string
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&CustomClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			return s.secretKey, nil
-		},
+		s.keyFunc,
 	)
 
-	if err != nil {
-		// 이미 만료된 토큰이라도 파싱 시도
-		// jwt.ErrTokenExpired인 경우에도 claims는 추출 가능
+	// 이미 만료된 토큰(jwt.ErrTokenExpired)도 claims는 추출 가능
+	// JWT가 아니면(Personal Access Token 등) token이 nil
+	if err != nil && token == nil {
+		return ErrInvalidToken
 	}
 
 	claims, ok := token.Claims.(*CustomClaims)
-	if !ok {
+	if !ok || claims.ExpiresAt == nil {
 		return ErrInvalidToken
 	}
 
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,17 +12,24 @@ import (
 
 // TokenStore는 토큰 저장소 인터페이스입니다.
 type TokenStore interface {
-	// StoreRefreshToken은 Refresh Token을 저장합니다.
-	StoreRefreshToken(ctx context.Context, userID uint, tokenID string, expiry time.Duration) error
+	// SaveSession은 세션을 저장(생성/갱신)합니다. expiry가 지나면 자동 만료됩니다.
+	SaveSession(ctx context.Context, session *Session, expiry time.Duration) error
 
-	// GetRefreshToken은 저장된 Refresh Token ID를 조회합니다.
-	GetRefreshToken(ctx context.Context, userID uint) (string, error)
+	// SwapSession은 저장된 세션의 TokenID가 prevTokenID일 때만 세션을 저장합니다. (compare-and-swap)
+	// 세션이 없으면 ErrSessionNotFound, 그 사이 다른 토큰으로 교체되었으면 ErrSessionRotated를 반환합니다.
+	SwapSession(ctx context.Context, session *Session, prevTokenID string, expiry time.Duration) error
 
-	// DeleteRefreshToken은 Refresh Token을 삭제합니다.
-	DeleteRefreshToken(ctx context.Context, userID uint) error
+	// GetSession은 세션을 조회합니다. 없으면 ErrSessionNotFound를 반환합니다.
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
 
-	// IsRefreshTokenValid는 Refresh Token이 유효한지 확인합니다.
-	IsRefreshTokenValid(ctx context.Context, userID uint, tokenID string) (bool, error)
+	// ListSessions는 사용자의 모든 세션을 조회합니다.
+	ListSessions(ctx context.Context, userID uint) ([]*Session, error)
+
+	// DeleteSession은 세션을 삭제합니다.
+	DeleteSession(ctx context.Context, userID uint, sessionID string) error
+
+	// DeleteAllSessions는 사용자의 모든 세션을 삭제합니다.
+	DeleteAllSessions(ctx context.Context, userID uint) error
 
 	// AddToBlacklist는 토큰을 블랙리스트에 추가합니다.
 	AddToBlacklist(ctx context.Context, tokenID string, expiry time.Duration) error
@@ -38,39 +47,165 @@ func NewRedisTokenStore(client *redis.Client) *RedisTokenStore {
 }
 
 // 키 형식
-func refreshTokenKey(userID uint) string {
-	return fmt.Sprintf("refresh_token:%d", userID)
+//
+//	session:{sessionID}    세션 JSON
+//	user_sessions:{userID} 사용자의 세션 ID 목록 (Set)
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
 }
 
 func blacklistKey(tokenID string) string {
 	return fmt.Sprintf("blacklist:%s", tokenID)
 }
 
-func (s *RedisTokenStore) StoreRefreshToken(ctx context.Context, userID uint, tokenID string, expiry time.Duration) error {
-	key := refreshTokenKey(userID)
-	return s.client.Set(ctx, key, tokenID, expiry).Err()
+func (s *RedisTokenStore) SaveSession(ctx context.Context, session *Session, expiry time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	indexKey := userSessionsKey(session.UserID)
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, sessionKey(session.ID), data, expiry)
+	pipe.SAdd(ctx, indexKey, session.ID)
+	// 세션 만료 시간이 모두 같으므로 마지막으로 저장된 세션 기준으로 목록 만료 연장
+	pipe.Expire(ctx, indexKey, expiry)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// SwapSession은 WATCH로 세션 키를 감시하며 교체합니다.
+// 확인과 저장 사이에 다른 요청이 세션을 바꾸면 트랜잭션이 실패하고 ErrSessionRotated를 반환합니다.
+func (s *RedisTokenStore) SwapSession(ctx context.Context, session *Session, prevTokenID string, expiry time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	key := sessionKey(session.ID)
+	indexKey := userSessionsKey(session.UserID)
+
+	err = s.client.Watch(ctx, func(tx *redis.Tx) error {
+		stored, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var current Session
+		if err := json.Unmarshal(stored, &current); err != nil {
+			return err
+		}
+		if current.TokenID != prevTokenID {
+			return ErrSessionRotated
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, expiry)
+			pipe.SAdd(ctx, indexKey, session.ID)
+			pipe.Expire(ctx, indexKey, expiry)
+			return nil
+		})
+		return err
+	}, key)
+
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrSessionRotated
+	}
+	return err
 }
 
-func (s *RedisTokenStore) GetRefreshToken(ctx context.Context, userID uint) (string, error) {
-	key := refreshTokenKey(userID)
-	tokenID, err := s.client.Get(ctx, key).Result()
+func (s *RedisTokenStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := s.client.Get(ctx, sessionKey(sessionID)).Bytes()
 	if err == redis.Nil {
-		return "", nil
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
 	}
-	return tokenID, err
+	return &session, nil
 }
 
-func (s *RedisTokenStore) DeleteRefreshToken(ctx context.Context, userID uint) error {
-	key := refreshTokenKey(userID)
-	return s.client.Del(ctx, key).Err()
+func (s *RedisTokenStore) ListSessions(ctx context.Context, userID uint) ([]*Session, error) {
+	indexKey := userSessionsKey(userID)
+
+	ids, err := s.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKey(id)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(values))
+	var expired []interface{}
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			// 만료된 세션 - 목록에서 정리
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var session Session
+		if err := json.Unmarshal([]byte(str), &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expired) > 0 {
+		_ = s.client.SRem(ctx, indexKey, expired...).Err()
+	}
+
+	return sessions, nil
 }
 
-func (s *RedisTokenStore) IsRefreshTokenValid(ctx context.Context, userID uint, tokenID string) (bool, error) {
-	storedID, err := s.GetRefreshToken(ctx, userID)
+func (s *RedisTokenStore) DeleteSession(ctx context.Context, userID uint, sessionID string) error {
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, sessionKey(sessionID))
+	pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *RedisTokenStore) DeleteAllSessions(ctx context.Context, userID uint) error {
+	indexKey := userSessionsKey(userID)
+
+	ids, err := s.client.SMembers(ctx, indexKey).Result()
 	if err != nil {
-		return false, err
+		return err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
 	}
-	return storedID == tokenID, nil
+	keys = append(keys, indexKey)
+
+	return s.client.Del(ctx, keys...).Err()
 }
 
 func (s *RedisTokenStore) AddToBlacklist(ctx context.Context, tokenID string, expiry time.Duration) error {
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryTokenStore는 메모리 기반 TokenStore입니다.
+//
+//	단일 인스턴스 운영과 테스트용 - 여러 대로 운영하거나 재시작 후에도 세션을 유지하려면 RedisTokenStore를 사용해야 합니다.
+//	만료된 항목은 조회 시 제외되고, 백그라운드 sweeper가 주기적으로 삭제합니다.
+type MemoryTokenStore struct {
+	mu        sync.RWMutex
+	sessions  map[string]memorySession
+	userIndex map[uint]map[string]struct{} // userID -> 세션 ID 목록
+	blacklist map[string]time.Time         // tokenID -> 만료 시각 (zero면 만료 없음)
+	now       func() time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type memorySession struct {
+	session   Session
+	expiresAt time.Time // zero면 만료 없음
+}
+
+// NewMemoryTokenStore는 MemoryTokenStore를 생성하고 sweepInterval마다 만료 항목을 정리합니다.
+// sweepInterval이 0 이하이면 1분입니다. 사용이 끝나면 Close로 sweeper를 종료합니다.
+func NewMemoryTokenStore(sweepInterval time.Duration) *MemoryTokenStore {
+	if sweepInterval <= 0 {
+		sweepInterval = time.Minute
+	}
+
+	s := &MemoryTokenStore{
+		sessions:  make(map[string]memorySession),
+		userIndex: make(map[uint]map[string]struct{}),
+		blacklist: make(map[string]time.Time),
+		now:       time.Now,
+		stop:      make(chan struct{}),
+	}
+	go s.sweepLoop(sweepInterval)
+	return s
+}
+
+// Close는 백그라운드 sweeper를 종료합니다.
+func (s *MemoryTokenStore) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+func (s *MemoryTokenStore) SaveSession(ctx context.Context, session *Session, expiry time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = memorySession{session: *session, expiresAt: s.expiresAt(expiry)}
+
+	ids, ok := s.userIndex[session.UserID]
+	if !ok {
+		ids = make(map[string]struct{})
+		s.userIndex[session.UserID] = ids
+	}
+	ids[session.ID] = struct{}{}
+	return nil
+}
+
+func (s *MemoryTokenStore) SwapSession(ctx context.Context, session *Session, prevTokenID string, expiry time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.sessions[session.ID]
+	if !ok || isExpired(entry.expiresAt, s.now()) {
+		return ErrSessionNotFound
+	}
+	if entry.session.TokenID != prevTokenID {
+		return ErrSessionRotated
+	}
+
+	s.sessions[session.ID] = memorySession{session: *session, expiresAt: s.expiresAt(expiry)}
+	return nil
+}
+
+func (s *MemoryTokenStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.sessions[sessionID]
+	if !ok || isExpired(entry.expiresAt, s.now()) {
+		return nil, ErrSessionNotFound
+	}
+
+	session := entry.session
+	return &session, nil
+}
+
+func (s *MemoryTokenStore) ListSessions(ctx context.Context, userID uint) ([]*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	sessions := make([]*Session, 0, len(s.userIndex[userID]))
+	for id := range s.userIndex[userID] {
+		entry, ok := s.sessions[id]
+		if !ok || isExpired(entry.expiresAt, now) {
+			continue
+		}
+		session := entry.session
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+func (s *MemoryTokenStore) DeleteSession(ctx context.Context, userID uint, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+	s.removeFromIndex(userID, sessionID)
+	return nil
+}
+
+func (s *MemoryTokenStore) DeleteAllSessions(ctx context.Context, userID uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.userIndex[userID] {
+		delete(s.sessions, id)
+	}
+	delete(s.userIndex, userID)
+	return nil
+}
+
+func (s *MemoryTokenStore) AddToBlacklist(ctx context.Context, tokenID string, expiry time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blacklist[tokenID] = s.expiresAt(expiry)
+	return nil
+}
+
+func (s *MemoryTokenStore) IsBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	at, ok := s.blacklist[tokenID]
+	return ok && !isExpired(at, s.now()), nil
+}
+
+// removeFromIndex는 사용자 세션 목록에서 세션을 제거합니다. (호출 시 lock 필요)
+func (s *MemoryTokenStore) removeFromIndex(userID uint, sessionID string) {
+	ids, ok := s.userIndex[userID]
+	if !ok {
+		return
+	}
+	delete(ids, sessionID)
+	if len(ids) == 0 {
+		delete(s.userIndex, userID)
+	}
+}
+
+func (s *MemoryTokenStore) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// sweep은 만료된 세션과 블랙리스트 항목을 삭제합니다.
+func (s *MemoryTokenStore) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, entry := range s.sessions {
+		if isExpired(entry.expiresAt, now) {
+			delete(s.sessions, id)
+			s.removeFromIndex(entry.session.UserID, id)
+		}
+	}
+	for id, at := range s.blacklist {
+		if isExpired(at, now) {
+			delete(s.blacklist, id)
+		}
+	}
+}
+
+// expiresAt은 expiry 후의 만료 시각을 반환합니다. expiry가 0 이하이면 만료 없음(zero)입니다.
+func (s *MemoryTokenStore) expiresAt(expiry time.Duration) time.Time {
+	if expiry <= 0 {
+		return time.Time{}
+	}
+	return s.now().Add(expiry)
+}
+
+func isExpired(at, now time.Time) bool {
+	return !at.IsZero() && !now.Before(at)
+}
@@ -0,0 +1,407 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+// TokenStoreSuite는 모든 TokenStore 구현이 만족해야 하는 동작을 검증합니다.
+type TokenStoreSuite struct {
+	suite.Suite
+
+	// newStore는 빈 저장소와 시간을 앞당기는 함수, 정리 함수를 반환합니다.
+	newStore func() (store TokenStore, advance func(time.Duration), cleanup func())
+
+	store   TokenStore
+	advance func(time.Duration)
+	cleanup func()
+	ctx     context.Context
+}
+
+func (s *TokenStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.store, s.advance, s.cleanup = s.newStore()
+}
+
+func (s *TokenStoreSuite) TearDownTest() {
+	s.cleanup()
+}
+
+func newTestSession(id string, userID uint) *Session {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &Session{
+		ID:         id,
+		UserID:     userID,
+		TokenID:    "jti-" + id,
+		DeviceName: "laptop",
+		UserAgent:  "curl/8.0",
+		IP:         "127.0.0.1",
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+}
+
+func (s *TokenStoreSuite) assertSession(want, got *Session) {
+	s.Require().NotNil(got)
+	s.Equal(want.ID, got.ID)
+	s.Equal(want.UserID, got.UserID)
+	s.Equal(want.TokenID, got.TokenID)
+	s.Equal(want.DeviceName, got.DeviceName)
+	s.Equal(want.UserAgent, got.UserAgent)
+	s.Equal(want.IP, got.IP)
+	s.Equal(want.TwoFactor, got.TwoFactor)
+	s.True(want.CreatedAt.Equal(got.CreatedAt))
+	s.True(want.LastUsedAt.Equal(got.LastUsedAt))
+}
+
+func (s *TokenStoreSuite) sessionIDs(sessions []*Session) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+func (s *TokenStoreSuite) TestSaveAndGetSession() {
+	session := newTestSession("s1", 1)
+	session.TwoFactor = true
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+
+	got, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.assertSession(session, got)
+}
+
+func (s *TokenStoreSuite) TestGetSession_NotFound() {
+	_, err := s.store.GetSession(s.ctx, "missing")
+	s.ErrorIs(err, ErrSessionNotFound)
+}
+
+func (s *TokenStoreSuite) TestSaveSession_Overwrites() {
+	session := newTestSession("s1", 1)
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+
+	// Rotation - 같은 세션에 새 jti 저장
+	session.TokenID = "jti-rotated"
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+
+	got, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.Equal("jti-rotated", got.TokenID)
+
+	sessions, err := s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Len(sessions, 1)
+}
+
+func (s *TokenStoreSuite) TestGetSession_ReturnsCopy() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("s1", 1), time.Hour))
+
+	got, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	got.TokenID = "modified"
+
+	again, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.Equal("jti-s1", again.TokenID)
+}
+
+func (s *TokenStoreSuite) TestListSessions() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a1", 1), time.Hour))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a2", 1), time.Hour))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("b1", 2), time.Hour))
+
+	sessions, err := s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"a1", "a2"}, s.sessionIDs(sessions))
+
+	sessions, err = s.store.ListSessions(s.ctx, 3)
+	s.Require().NoError(err)
+	s.NotNil(sessions)
+	s.Empty(sessions)
+}
+
+func (s *TokenStoreSuite) TestDeleteSession() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a1", 1), time.Hour))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a2", 1), time.Hour))
+
+	s.Require().NoError(s.store.DeleteSession(s.ctx, 1, "a1"))
+
+	_, err := s.store.GetSession(s.ctx, "a1")
+	s.ErrorIs(err, ErrSessionNotFound)
+
+	sessions, err := s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Equal([]string{"a2"}, s.sessionIDs(sessions))
+
+	// 없는 세션 삭제는 에러 아님
+	s.NoError(s.store.DeleteSession(s.ctx, 1, "missing"))
+}
+
+func (s *TokenStoreSuite) TestDeleteAllSessions() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a1", 1), time.Hour))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("a2", 1), time.Hour))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("b1", 2), time.Hour))
+
+	s.Require().NoError(s.store.DeleteAllSessions(s.ctx, 1))
+
+	sessions, err := s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Empty(sessions)
+
+	_, err = s.store.GetSession(s.ctx, "a2")
+	s.ErrorIs(err, ErrSessionNotFound)
+
+	// 다른 사용자의 세션은 유지
+	got, err := s.store.GetSession(s.ctx, "b1")
+	s.Require().NoError(err)
+	s.Equal(uint(2), got.UserID)
+
+	s.NoError(s.store.DeleteAllSessions(s.ctx, 99))
+}
+
+func (s *TokenStoreSuite) TestSwapSession() {
+	session := newTestSession("s1", 1)
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+
+	rotated := *session
+	rotated.TokenID = "jti-next"
+	s.Require().NoError(s.store.SwapSession(s.ctx, &rotated, "jti-s1", time.Hour))
+
+	got, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.assertSession(&rotated, got)
+
+	// 이미 교체된 토큰 기준으로는 교체되지 않음
+	stale := *session
+	stale.TokenID = "jti-forked"
+	s.ErrorIs(s.store.SwapSession(s.ctx, &stale, "jti-s1", time.Hour), ErrSessionRotated)
+
+	got, err = s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.Equal("jti-next", got.TokenID)
+}
+
+func (s *TokenStoreSuite) TestSwapSession_NotFound() {
+	session := newTestSession("missing", 1)
+	s.ErrorIs(s.store.SwapSession(s.ctx, session, "jti-missing", time.Hour), ErrSessionNotFound)
+
+	_, err := s.store.GetSession(s.ctx, "missing")
+	s.ErrorIs(err, ErrSessionNotFound)
+}
+
+// 같은 이전 토큰으로 동시에 교체하면 하나만 성공해야 합니다.
+func (s *TokenStoreSuite) TestSwapSession_Concurrent() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("s1", 1), time.Hour))
+
+	const workers = 20
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded []string
+	)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			next := newTestSession("s1", 1)
+			next.TokenID = fmt.Sprintf("jti-%d", i)
+
+			err := s.store.SwapSession(s.ctx, next, "jti-s1", time.Hour)
+			if err == nil {
+				mu.Lock()
+				succeeded = append(succeeded, next.TokenID)
+				mu.Unlock()
+				return
+			}
+			s.ErrorIs(err, ErrSessionRotated)
+		}()
+	}
+	wg.Wait()
+
+	s.Require().Len(succeeded, 1)
+	got, err := s.store.GetSession(s.ctx, "s1")
+	s.Require().NoError(err)
+	s.Equal(succeeded[0], got.TokenID)
+}
+
+func (s *TokenStoreSuite) TestSessionExpiry() {
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("short", 1), time.Minute))
+	s.Require().NoError(s.store.SaveSession(s.ctx, newTestSession("long", 1), time.Hour))
+
+	s.advance(2 * time.Minute)
+
+	_, err := s.store.GetSession(s.ctx, "short")
+	s.ErrorIs(err, ErrSessionNotFound)
+
+	got, err := s.store.GetSession(s.ctx, "long")
+	s.Require().NoError(err)
+	s.Equal("long", got.ID)
+
+	sessions, err := s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Equal([]string{"long"}, s.sessionIDs(sessions))
+
+	s.advance(time.Hour)
+
+	sessions, err = s.store.ListSessions(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Empty(sessions)
+}
+
+func (s *TokenStoreSuite) TestSaveSession_ExtendsExpiry() {
+	session := newTestSession("s1", 1)
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+
+	s.advance(50 * time.Minute)
+	s.Require().NoError(s.store.SaveSession(s.ctx, session, time.Hour))
+	s.advance(50 * time.Minute)
+
+	_, err := s.store.GetSession(s.ctx, "s1")
+	s.NoError(err)
+}
+
+func (s *TokenStoreSuite) TestBlacklist() {
+	blacklisted, err := s.store.IsBlacklisted(s.ctx, "jti-1")
+	s.Require().NoError(err)
+	s.False(blacklisted)
+
+	s.Require().NoError(s.store.AddToBlacklist(s.ctx, "jti-1", time.Hour))
+
+	blacklisted, err = s.store.IsBlacklisted(s.ctx, "jti-1")
+	s.Require().NoError(err)
+	s.True(blacklisted)
+
+	blacklisted, err = s.store.IsBlacklisted(s.ctx, "jti-2")
+	s.Require().NoError(err)
+	s.False(blacklisted)
+}
+
+func (s *TokenStoreSuite) TestBlacklistExpiry() {
+	s.Require().NoError(s.store.AddToBlacklist(s.ctx, "jti-1", time.Minute))
+
+	s.advance(2 * time.Minute)
+
+	blacklisted, err := s.store.IsBlacklisted(s.ctx, "jti-1")
+	s.Require().NoError(err)
+	s.False(blacklisted)
+}
+
+func (s *TokenStoreSuite) TestConcurrentAccess() {
+	const workers = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("s%d", i)
+			userID := uint(i%3 + 1)
+
+			s.NoError(s.store.SaveSession(s.ctx, newTestSession(id, userID), time.Hour))
+			_, err := s.store.GetSession(s.ctx, id)
+			s.NoError(err)
+			_, err = s.store.ListSessions(s.ctx, userID)
+			s.NoError(err)
+			s.NoError(s.store.AddToBlacklist(s.ctx, "jti-"+id, time.Hour))
+			_, err = s.store.IsBlacklisted(s.ctx, "jti-"+id)
+			s.NoError(err)
+			if i%2 == 0 {
+				s.NoError(s.store.DeleteSession(s.ctx, userID, id))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for userID := uint(1); userID <= 3; userID++ {
+		sessions, err := s.store.ListSessions(s.ctx, userID)
+		s.Require().NoError(err)
+		total += len(sessions)
+	}
+	s.Equal(workers/2, total)
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	suite.Run(t, &TokenStoreSuite{
+		newStore: func() (TokenStore, func(time.Duration), func()) {
+			var mu sync.Mutex
+			clock := time.Now()
+
+			store := NewMemoryTokenStore(time.Hour)
+			store.now = func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				return clock
+			}
+			advance := func(d time.Duration) {
+				mu.Lock()
+				defer mu.Unlock()
+				clock = clock.Add(d)
+			}
+			return store, advance, store.Close
+		},
+	})
+}
+
+func TestRedisTokenStore(t *testing.T) {
+	suite.Run(t, &TokenStoreSuite{
+		newStore: func() (TokenStore, func(time.Duration), func()) {
+			mr, err := miniredis.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+			cleanup := func() {
+				client.Close()
+				mr.Close()
+			}
+			return NewRedisTokenStore(client), mr.FastForward, cleanup
+		},
+	})
+}
+
+// 만료 항목은 조회에서 제외될 뿐 아니라 sweeper가 메모리에서 삭제해야 합니다.
+func TestMemoryTokenStore_Sweep(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+
+	store := NewMemoryTokenStore(time.Hour)
+	defer store.Close()
+	store.now = func() time.Time { return clock }
+
+	store.SaveSession(ctx, newTestSession("short", 1), time.Minute)
+	store.SaveSession(ctx, newTestSession("long", 1), time.Hour)
+	store.AddToBlacklist(ctx, "jti-short", time.Minute)
+	store.AddToBlacklist(ctx, "jti-forever", 0)
+
+	clock = clock.Add(2 * time.Minute)
+	store.sweep()
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if _, ok := store.sessions["short"]; ok {
+		t.Error("expired session not swept")
+	}
+	if _, ok := store.sessions["long"]; !ok {
+		t.Error("live session swept")
+	}
+	if _, ok := store.userIndex[1]["short"]; ok {
+		t.Error("expired session still indexed")
+	}
+	if _, ok := store.blacklist["jti-short"]; ok {
+		t.Error("expired blacklist entry not swept")
+	}
+	if _, ok := store.blacklist["jti-forever"]; !ok {
+		t.Error("blacklist entry without expiry swept")
+	}
+}
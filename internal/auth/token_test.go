@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTokenService는 메모리 키/세션 저장소를 쓰는 TokenService를 만듭니다.
+func newTestTokenService(t *testing.T) (*TokenService, *MemoryTokenStore) {
+	t.Helper()
+
+	keys, err := NewKeyManager(context.Background(), NewMemoryKeyStore(), KeyManagerConfig{Algorithm: AlgEdDSA})
+	require.NoError(t, err)
+
+	store := NewMemoryTokenStore(time.Hour)
+	t.Cleanup(store.Close)
+
+	return NewTokenService(keys, 15*time.Minute, 24*time.Hour, store), store
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	tokens, store := newTestTokenService(t)
+
+	first, _, err := tokens.CreateSession(ctx, 1, SessionMeta{DeviceName: "laptop"})
+	require.NoError(t, err)
+
+	session, err := tokens.ValidateRefreshToken(ctx, first)
+	require.NoError(t, err)
+
+	second, err := tokens.RotateRefreshToken(ctx, session)
+	require.NoError(t, err)
+
+	session, err = tokens.ValidateRefreshToken(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), session.UserID)
+
+	// 교체된 토큰 재사용 - 세션 전체 폐기
+	_, err = tokens.ValidateRefreshToken(ctx, first)
+	var reuseErr *TokenReuseError
+	require.ErrorAs(t, err, &reuseErr)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Equal(t, session.ID, reuseErr.SessionID)
+
+	_, err = store.GetSession(ctx, session.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	_, err = tokens.ValidateRefreshToken(ctx, second)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+// 같은 토큰으로 동시에 갱신하면 한 요청만 새 토큰을 받고, 패밀리가 갈라지지 않도록 세션이 폐기됩니다.
+func TestRotateRefreshToken_ConcurrentRefresh(t *testing.T) {
+	ctx := context.Background()
+	tokens, store := newTestTokenService(t)
+
+	refreshToken, created, err := tokens.CreateSession(ctx, 1, SessionMeta{})
+	require.NoError(t, err)
+
+	const workers = 10
+	sessions := make([]*Session, workers)
+	for i := range sessions {
+		sessions[i], err = tokens.ValidateRefreshToken(ctx, refreshToken)
+		require.NoError(t, err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		rotated int
+		reused  int
+	)
+	for _, session := range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := tokens.RotateRefreshToken(ctx, session)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				rotated++
+			case errors.Is(err, ErrRefreshTokenReused), errors.Is(err, ErrInvalidToken):
+				reused++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, rotated)
+	assert.Equal(t, workers-1, reused)
+
+	_, err = store.GetSession(ctx, created.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestRotateRefreshToken_LoggedOutSession(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newTestTokenService(t)
+
+	refreshToken, created, err := tokens.CreateSession(ctx, 1, SessionMeta{})
+	require.NoError(t, err)
+
+	session, err := tokens.ValidateRefreshToken(ctx, refreshToken)
+	require.NoError(t, err)
+	require.NoError(t, tokens.RevokeSession(ctx, 1, created.ID))
+
+	_, err = tokens.RotateRefreshToken(ctx, session)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestGetSession(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newTestTokenService(t)
+
+	_, created, err := tokens.CreateSession(ctx, 1, SessionMeta{})
+	require.NoError(t, err)
+
+	session, err := tokens.GetSession(ctx, 1, created.ID)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), session.CreatedAt, time.Second)
+
+	// 다른 사용자의 세션, 세션 없는 요청 (Personal Access Token)
+	_, err = tokens.GetSession(ctx, 2, created.ID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	_, err = tokens.GetSession(ctx, 1, "")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newTestTokenService(t)
+
+	_, session, err := tokens.CreateSession(ctx, 1, SessionMeta{})
+	require.NoError(t, err)
+	token, err := tokens.GenerateAccessToken(TokenSubject{UserID: 1, Email: "user@example.com", Role: "user"}, session)
+	require.NoError(t, err)
+
+	require.NoError(t, tokens.RevokeAccessToken(ctx, token))
+	_, err = tokens.IntrospectAccessToken(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// JWT가 아닌 토큰은 panic 없이 거부
+	personal, err := GeneratePersonalToken()
+	require.NoError(t, err)
+	assert.ErrorIs(t, tokens.RevokeAccessToken(ctx, personal), ErrInvalidToken)
+	assert.ErrorIs(t, tokens.RevokeAccessToken(ctx, "not-a-token"), ErrInvalidToken)
+}
+
+func TestImpersonationToken_ActClaim(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newTestTokenService(t)
+
+	_, adminSession, err := tokens.CreateSession(ctx, 1, SessionMeta{})
+	require.NoError(t, err)
+
+	token, err := tokens.GenerateImpersonationToken(
+		TokenSubject{UserID: 2, Email: "user@example.com", Role: "user"},
+		ActorClaim{Subject: "1", UserID: 1, Email: "admin@example.com"},
+		adminSession.ID, time.Minute,
+	)
+	require.NoError(t, err)
+
+	// 페이로드에 RFC 8693 act 클레임으로 관리자 정보가 담김
+	var raw jwt.MapClaims
+	_, _, err = jwt.NewParser().ParseUnverified(token, &raw)
+	require.NoError(t, err)
+	assert.Equal(t, "2", raw["sub"])
+	assert.Equal(t, map[string]any{"sub": "1", "user_id": float64(1), "email": "admin@example.com"}, raw["act"])
+
+	claims, err := tokens.ValidateToken(token)
+	require.NoError(t, err)
+	assert.True(t, claims.IsImpersonated())
+	assert.Equal(t, uint(2), claims.UserID)
+	assert.Equal(t, uint(1), claims.Actor.UserID)
+	assert.Equal(t, adminSession.ID, claims.SessionID) // 관리자 세션에 묶임
+
+	// 일반 토큰에는 act가 없음
+	normal, err := tokens.GenerateAccessToken(TokenSubject{UserID: 2, Email: "user@example.com", Role: "user"}, adminSession)
+	require.NoError(t, err)
+	claims, err = tokens.ValidateToken(normal)
+	require.NoError(t, err)
+	assert.False(t, claims.IsImpersonated())
+
+	// 자기 자신으로 대리 로그인한 토큰은 거부
+	self, err := tokens.GenerateImpersonationToken(
+		TokenSubject{UserID: 1, Email: "admin@example.com", Role: "admin"},
+		ActorClaim{Subject: "1", UserID: 1, Email: "admin@example.com"},
+		adminSession.ID, time.Minute,
+	)
+	require.NoError(t, err)
+	_, err = tokens.ValidateToken(self)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,11 +12,161 @@ import (
 var cfg *Config
 
 type Config struct {
-	Server     ServerConfig
-	Database   DatabaseConfig
-	Pagination PaginationConfig
-	Logging    LoggingConfig
-	Sentry     SentryConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Redis         RedisConfig
+	JWT           JWTConfig `mapstructure:"jwt"`
+	Pagination    PaginationConfig
+	Logging       LoggingConfig
+	Sentry        SentryConfig
+	ContentFilter ContentFilterConfig `mapstructure:"content_filter"`
+	Auth          AuthConfig
+	Mail          MailConfig
+	OIDC          OIDCConfig      `mapstructure:"oidc"`
+	OAuth         OAuthConfig     `mapstructure:"oauth"`
+	RateLimit     RateLimitConfig `mapstructure:"rate_limit"`
+}
+
+// RateLimitConfig 라우트 그룹별 Rate Limit 설정
+type RateLimitConfig struct {
+	Store    string                           `mapstructure:"store"`     // redis (모든 인스턴스가 한도 공유) 또는 memory (인스턴스별)
+	FailOpen bool                             `mapstructure:"fail_open"` // 저장소 장애 시 요청 허용 여부 기본값 (false면 503)
+	Policies map[string]RateLimitPolicyConfig `mapstructure:"policies"`  // 정책 이름 -> 한도 (라우터에서 이름으로 사용)
+}
+
+// RateLimitPolicyConfig period 동안 limit회, 최대 burst회까지 연속 허용
+type RateLimitPolicyConfig struct {
+	Limit    int           `mapstructure:"limit"`
+	Period   time.Duration `mapstructure:"period"`
+	Burst    int           `mapstructure:"burst"`     // 0이면 limit
+	Key      string        `mapstructure:"key"`       // ip, user, api_key
+	FailOpen *bool         `mapstructure:"fail_open"` // 없으면 rate_limit.fail_open
+}
+
+// OAuthConfig 토큰 확인/폐기 API(/oauth/introspect, /oauth/revoke)를 호출할 내부 서비스
+type OAuthConfig struct {
+	Clients []OAuthClientConfig `mapstructure:"clients"`
+}
+
+type OAuthClientConfig struct {
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+}
+
+// OIDCConfig 외부 IdP(OpenID Connect) 로그인 설정
+type OIDCConfig struct {
+	StateSecret string               `mapstructure:"state_secret"` // 로그인 진행 상태 쿠키 서명 키
+	Providers   []OIDCProviderConfig `mapstructure:"providers"`
+}
+
+type OIDCProviderConfig struct {
+	Name         string   `mapstructure:"name"` // URL에 사용 (/oidc/{name}/login)
+	Issuer       string   `mapstructure:"issuer"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"`
+}
+
+// AuthConfig 계정/인증 정책 설정
+type AuthConfig struct {
+	AppBaseURL           string        `mapstructure:"app_base_url"`            // 메일 링크에 사용할 프론트엔드 주소
+	EmailVerificationTTL time.Duration `mapstructure:"email_verification_ttl"`  // 인증 메일 토큰 유효 시간
+	PasswordResetTTL     time.Duration `mapstructure:"password_reset_ttl"`      // 비밀번호 재설정 토큰 유효 시간
+	PasswordResetPerHour int           `mapstructure:"password_reset_per_hour"` // 계정당 시간당 재설정 메일 발송 한도
+	TokenStore           string        `mapstructure:"token_store"`             // 세션/블랙리스트 저장소: redis 또는 memory (단일 인스턴스)
+	AccountDeletionGrace time.Duration `mapstructure:"account_deletion_grace"`  // 탈퇴 요청 후 취소할 수 있는 기간
+	NewDeviceAlert       bool          `mapstructure:"new_device_alert"`        // 처음 보는 기기/위치에서 로그인하면 메일로 알림
+
+	// 2단계 인증 (TOTP)
+	TOTPIssuer            string        `mapstructure:"totp_issuer"`              // 인증 앱에 표시할 서비스 이름
+	TwoFactorChallengeTTL time.Duration `mapstructure:"two_factor_challenge_ttl"` // 비밀번호 확인 후 코드 입력 제한 시간
+	RequireAdmin2FA       bool          `mapstructure:"require_admin_2fa"`        // 관리자 API는 2단계 인증 세션에서만 사용 (관리자 권한이 있는 역할은 해제 불가)
+
+	Cookie         CookieConfig         `mapstructure:"cookie"`
+	Lockout        LockoutConfig        `mapstructure:"lockout"`
+	PasswordHash   PasswordHashConfig   `mapstructure:"password_hash"`
+	PasswordPolicy PasswordPolicyConfig `mapstructure:"password_policy"`
+}
+
+// CookieConfig 브라우저 쿠키 세션 설정 (Secure 속성은 server.mode가 release일 때 적용)
+type CookieConfig struct {
+	Domain   string `mapstructure:"domain"`    // 비우면 API 호스트에만 전송
+	SameSite string `mapstructure:"same_site"` // lax(기본), strict, none (none은 HTTPS 필요)
+}
+
+// PasswordPolicyConfig 비밀번호 정책 설정
+// 회원가입/비밀번호 재설정 시 검사하고, 기존 비밀번호에는 적용하지 않습니다.
+type PasswordPolicyConfig struct {
+	MinLength      int  `mapstructure:"min_length"`       // 최소 길이 (0이면 기본 정책 사용)
+	MaxLength      int  `mapstructure:"max_length"`       // 최대 길이 (0이면 제한 없음)
+	RequireUpper   bool `mapstructure:"require_upper"`    // 대문자 포함
+	RequireLower   bool `mapstructure:"require_lower"`    // 소문자 포함
+	RequireDigit   bool `mapstructure:"require_digit"`    // 숫자 포함
+	RequireSpecial bool `mapstructure:"require_special"`  // 특수문자 포함
+	ForbidUserInfo bool `mapstructure:"forbid_user_info"` // 이메일/사용자 이름 포함 금지
+	Blocklist      bool `mapstructure:"blocklist"`        // 흔히 쓰이거나 유출된 비밀번호 차단
+}
+
+// PasswordHashConfig 비밀번호 해시 설정
+// 알고리즘이나 파라미터를 바꾸면 기존 사용자는 다음 로그인 때 새 해시로 교체됩니다.
+type PasswordHashConfig struct {
+	Algorithm         string `mapstructure:"algorithm"`          // argon2id 또는 bcrypt
+	Argon2Memory      uint32 `mapstructure:"argon2_memory"`      // 메모리 (KiB)
+	Argon2Iterations  uint32 `mapstructure:"argon2_iterations"`  // 반복 횟수
+	Argon2Parallelism uint8  `mapstructure:"argon2_parallelism"` // 병렬 스레드 수
+	BcryptCost        int    `mapstructure:"bcrypt_cost"`        // bcrypt 사용 시 cost (기존 해시 검증에는 해시에 기록된 cost 사용)
+}
+
+// LockoutConfig 로그인 실패 잠금 설정
+type LockoutConfig struct {
+	Store         string        `mapstructure:"store"`           // redis 또는 memory
+	MaxAttempts   int           `mapstructure:"max_attempts"`    // 계정당 허용 실패 횟수
+	MaxIPAttempts int           `mapstructure:"max_ip_attempts"` // IP당 허용 실패 횟수
+	BaseLockout   time.Duration `mapstructure:"base_lockout"`    // 첫 잠금 시간 (이후 실패마다 두 배)
+	MaxLockout    time.Duration `mapstructure:"max_lockout"`
+	Window        time.Duration `mapstructure:"window"` // 실패 횟수 유지 시간
+}
+
+// MailConfig 메일 발송 설정
+type MailConfig struct {
+	Transport string     `mapstructure:"transport"` // smtp 또는 file
+	From      string     `mapstructure:"from"`
+	OutboxDir string     `mapstructure:"outbox_dir"` // file transport 저장 위치
+	SMTP      SMTPConfig `mapstructure:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// ContentFilterConfig 게시글/댓글 스팸 필터 설정
+type ContentFilterConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// 금칙어 (일치 시 거부)
+	BlockedKeywords []string `mapstructure:"blocked_keywords"`
+	BlockedPatterns []string `mapstructure:"blocked_patterns"`
+
+	// 검토 대상 단어 (일치 시 검토 대기)
+	ReviewKeywords []string `mapstructure:"review_keywords"`
+	ReviewPatterns []string `mapstructure:"review_patterns"`
+
+	// 링크 수 제한 (0이면 비활성화)
+	MaxLinksHold   int `mapstructure:"max_links_hold"`
+	MaxLinksReject int `mapstructure:"max_links_reject"`
+
+	// 같은 내용 반복 작성 제한
+	DuplicateWindow     time.Duration `mapstructure:"duplicate_window"`
+	DuplicateMaxRepeats int           `mapstructure:"duplicate_max_repeats"`
+
+	// 신규 계정 작성 빈도 제한
+	NewAccountAge      time.Duration `mapstructure:"new_account_age"`
+	NewAccountWindow   time.Duration `mapstructure:"new_account_window"`
+	NewAccountMaxPosts int           `mapstructure:"new_account_max_posts"`
 }
 
 type SentryConfig struct {
@@ -26,6 +177,11 @@ type ServerConfig struct {
 	Port int    `mapstructure:"port"`
 	Mode string `mapstructure:"mode"`
 	Env  string `mapstructure:"env"`
+
+	// 클라이언트 IP(X-Forwarded-For)를 믿을 프록시 (IP 또는 CIDR, 비우면 연결 주소를 그대로 사용)
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	// 클라이언트 IP를 담아 주는 플랫폼 헤더 (예: CF-Connecting-IP)
+	TrustedPlatform string `mapstructure:"trusted_platform"`
 }
 
 type DatabaseConfig struct {
@@ -37,6 +193,12 @@ type DatabaseConfig struct {
 	SSLMode  string `mapstructure:"sslmode"`
 }
 
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
 type PaginationConfig struct {
 	DefaultSize int `mapstructure:"default_size"`
 	MaxSize     int `mapstructure:"max_size"`
@@ -50,10 +212,14 @@ type LoggingConfig struct {
 	SkipPaths     []string `mapstructure:"skip_paths"`
 }
 
+// JWTConfig 토큰 서명 설정
 type JWTConfig struct {
-	SecretKey     string        `mapstructure:"secret_key"`
-	AccessExpiry  time.Duration `mapstructure:"access_expiry"`
-	RefreshExpiry time.Duration `mapstructure:"refresh_expiry"`
+	AccessExpiry     time.Duration `mapstructure:"access_expiry"`
+	RefreshExpiry    time.Duration `mapstructure:"refresh_expiry"`
+	Algorithm        string        `mapstructure:"algorithm"`         // RS256 또는 EdDSA
+	KeysDir          string        `mapstructure:"keys_dir"`          // 서명 키(PEM) 디렉터리, 비우면 메모리 (재시작 시 토큰 무효)
+	RotationInterval time.Duration `mapstructure:"rotation_interval"` // 서명 키 교체 주기
+	GracePeriod      time.Duration `mapstructure:"grace_period"`      // 교체된 키로 검증을 허용하는 기간 (refresh_expiry 이상)
 }
 
 // DSN 데이터베이스 연결 문자열 생성
@@ -87,11 +253,23 @@ func Load(path string) (*Config, error) {
 	if err := viper.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("설정 파싱 실패: %w", err)
 	}
+	cfg.expandSecrets()
 
 	log.Printf("설정 로드 완료: %s", path)
 	return cfg, nil
 }
 
+// expandSecrets 비밀 값의 ${ENV} 참조를 환경 변수 값으로 치환 (viper는 값 안의 참조를 치환하지 않음)
+func (c *Config) expandSecrets() {
+	c.OIDC.StateSecret = os.ExpandEnv(c.OIDC.StateSecret)
+	for i := range c.OIDC.Providers {
+		c.OIDC.Providers[i].ClientSecret = os.ExpandEnv(c.OIDC.Providers[i].ClientSecret)
+	}
+	for i := range c.OAuth.Clients {
+		c.OAuth.Clients[i].ClientSecret = os.ExpandEnv(c.OAuth.Clients[i].ClientSecret)
+	}
+}
+
 // Get 전역 설정 반환
 func Get() *Config {
 	return cfg
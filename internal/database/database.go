@@ -32,19 +32,101 @@ func Init(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	// comment_count 컬럼이 새로 추가되는 경우 기존 댓글 수로 채워야 함
+	needsCommentCountBackfill := db.Migrator().HasTable(&domain.Post{}) &&
+		!db.Migrator().HasColumn(&domain.Post{}, "comment_count")
+
+	// path 컬럼이 새로 추가되는 경우 기존 댓글 트리로 경로를 채워야 함
+	needsCommentPathBackfill := db.Migrator().HasTable(&domain.Comment{}) &&
+		!db.Migrator().HasColumn(&domain.Comment{}, "path")
+
+	// email_verified_at 컬럼이 새로 추가되는 경우 기존 사용자는 인증된 것으로 처리
+	needsEmailVerifiedBackfill := db.Migrator().HasTable(&domain.User{}) &&
+		!db.Migrator().HasColumn(&domain.User{}, "email_verified_at")
+
 	// 자동 마이그레이션
 	if err := db.AutoMigrate(
 		&domain.Post{},
 		&domain.Comment{},
 		&domain.User{},
+		&domain.UserToken{},
+		&domain.RecoveryCode{},
+		&domain.UserIdentity{},
+		&domain.PersonalAccessToken{},
+		&domain.RoleDefinition{},
+		&domain.RolePermission{},
+		&domain.AuditEvent{},
+		&domain.LoginEvent{},
 	); err != nil {
 		return nil, err
 	}
 
+	if err := protectAuditEvents(db); err != nil {
+		return nil, err
+	}
+
+	if needsCommentCountBackfill {
+		if err := backfillCommentCounts(db); err != nil {
+			return nil, err
+		}
+	}
+
+	if needsCommentPathBackfill {
+		if err := backfillCommentPaths(db); err != nil {
+			return nil, err
+		}
+	}
+
+	if needsEmailVerifiedBackfill {
+		if err := db.Exec(`UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL`).Error; err != nil {
+			return nil, err
+		}
+	}
+
 	log.Println("데이터베이스 연결 완료")
 	return db, nil
 }
 
+// backfillCommentCounts 기존 게시글의 comment_count를 실제 댓글 수로 채운다.
+func backfillCommentCounts(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE posts SET comment_count = (
+			SELECT COUNT(*) FROM comments
+			WHERE comments.post_id = posts.id
+			  AND comments.deleted_at IS NULL
+			  AND comments.status = ?
+		)`, domain.ModerationApproved).Error
+}
+
+// backfillCommentPaths 기존 댓글의 Materialized Path를 parent_id 트리로부터 채운다.
+func backfillCommentPaths(db *gorm.DB) error {
+	return db.Exec(`
+		WITH RECURSIVE tree AS (
+			SELECT id, '/' || id || '/' AS path
+			FROM comments WHERE parent_id IS NULL
+			UNION ALL
+			SELECT c.id, t.path || c.id || '/'
+			FROM comments c JOIN tree t ON c.parent_id = t.id
+		)
+		UPDATE comments SET path = tree.path
+		FROM tree WHERE comments.id = tree.id`).Error
+}
+
+// protectAuditEvents 감사 로그 테이블의 수정/삭제를 막는 트리거를 만든다. (추가만 가능)
+func protectAuditEvents(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+		CREATE TRIGGER audit_events_append_only
+			BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_events
+			FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();`).Error
+}
+
 // Get DB 인스턴스 반환
 func Get() *gorm.DB {
 	return db
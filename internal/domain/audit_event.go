@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction 감사 로그 이벤트 종류 (영역.대상.동작)
+type AuditAction string
+
+const (
+	AuditLoginSucceeded     AuditAction = "auth.login.succeeded"
+	AuditLoginFailed        AuditAction = "auth.login.failed"
+	AuditLogout             AuditAction = "auth.logout"
+	AuditTokenRefreshed     AuditAction = "auth.token.refreshed"
+	AuditTokenReused        AuditAction = "auth.token.reused"  // 교체된 Refresh Token 재사용 (탈취 의심)
+	AuditTokenRevoked       AuditAction = "auth.token.revoked" // 내부 서비스가 토큰 폐기 (RFC 7009)
+	AuditPasswordChanged    AuditAction = "user.password.changed"
+	AuditPasswordReset      AuditAction = "user.password.reset"
+	AuditRoleChanged        AuditAction = "admin.user.role_changed"
+	AuditUserSuspended      AuditAction = "admin.user.suspended"
+	AuditUserUnsuspended    AuditAction = "admin.user.unsuspended"
+	AuditUserForceLoggedOut AuditAction = "admin.user.force_logged_out"
+	AuditUserDeleted        AuditAction = "admin.user.deleted"
+	AuditUserImpersonated   AuditAction = "admin.user.impersonated"
+	AuditRoleCreated        AuditAction = "admin.role.created"
+	AuditRoleUpdated        AuditAction = "admin.role.updated"
+	AuditRoleDeleted        AuditAction = "admin.role.deleted"
+	AuditUserUnlocked       AuditAction = "admin.user.unlocked"
+	AuditDeletionScheduled  AuditAction = "user.account.deletion_scheduled"
+)
+
+// AuditEvent는 보안 감사 로그입니다. 추가만 가능하고 수정/삭제할 수 없습니다. (DB 트리거로 차단)
+//
+//	각 이벤트는 직전 이벤트의 해시(PrevHash)를 포함한 해시(Hash)를 가지므로,
+//	중간 이벤트를 고치거나 지우면 이후 체인이 맞지 않아 변조를 확인할 수 있습니다.
+type AuditEvent struct {
+	ID        uint        `gorm:"primaryKey"`
+	Action    AuditAction `gorm:"size:64;not null;index"`
+	ActorID   *uint       `gorm:"index"` // 행위자 (비로그인 요청이면 nil, 대리 로그인이면 관리자)
+	TargetID  *uint       `gorm:"index"` // 대상 사용자
+	IP        string      `gorm:"size:45"`
+	UserAgent string      `gorm:"size:255"`
+	RequestID string      `gorm:"size:64;index"`
+	Metadata  string      `gorm:"type:json"` // jsonb는 키 순서/공백을 바꿔 해시가 달라지므로 원문을 보존하는 json 사용
+	PrevHash  string      `gorm:"size:64;not null"`
+	Hash      string      `gorm:"size:64;not null;uniqueIndex"`
+	CreatedAt time.Time   `gorm:"not null;index"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// ComputeHash는 직전 이벤트 해시와 이벤트 내용으로 SHA-256 해시(hex)를 계산합니다.
+// CreatedAt은 DB 정밀도(마이크로초)에 맞춰 저장해야 다시 계산한 값과 같습니다.
+func (e *AuditEvent) ComputeHash() string {
+	payload, _ := json.Marshal(struct {
+		PrevHash  string      `json:"prev_hash"`
+		Action    AuditAction `json:"action"`
+		ActorID   *uint       `json:"actor_id"`
+		TargetID  *uint       `json:"target_id"`
+		IP        string      `json:"ip"`
+		UserAgent string      `json:"user_agent"`
+		RequestID string      `json:"request_id"`
+		Metadata  string      `json:"metadata"`
+		CreatedAt string      `json:"created_at"`
+	}{
+		PrevHash:  e.PrevHash,
+		Action:    e.Action,
+		ActorID:   e.ActorID,
+		TargetID:  e.TargetID,
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Metadata:  e.Metadata,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,14 +10,17 @@ import (
 
 // Comment 댓글 도메인 모델
 type Comment struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	PostID    uint           `gorm:"not null;index" json:"post_id"`
-	ParentID  *uint          `gorm:"index" json:"parent_id,omitempty"` // 최상위 댓글의 경우 nil로 부모 없음을 표현한다.
-	Content   string         `gorm:"type:text;not null" json:"content"`
-	Author    string         `gorm:"size:50;not null" json:"author"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	PostID    uint             `gorm:"not null;index" json:"post_id"`
+	ParentID  *uint            `gorm:"index" json:"parent_id,omitempty"`                   // 최상위 댓글의 경우 nil로 부모 없음을 표현한다.
+	Path      string           `gorm:"size:255;index:,class:varchar_pattern_ops" json:"-"` // Materialized Path - 루트부터 자신까지의 ID (예: /1/5/12/)
+	Content   string           `gorm:"type:text;not null" json:"content"`
+	Author    string           `gorm:"size:50;not null" json:"author"`
+	AuthorID  *uint            `gorm:"index" json:"-"` // 작성한 사용자 (비로그인/이전 댓글은 nil)
+	Status    ModerationStatus `gorm:"size:20;default:approved;index" json:"status"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt gorm.DeletedAt   `gorm:"index" json:"-"`
 
 	// 연관관계
 	Post    Post      `gorm:"foreignKey:PostID" json:"-"`                   //다대일 (응답에서 제외)
@@ -27,3 +32,21 @@ type Comment struct {
 func (Comment) TableName() string {
 	return "comments"
 }
+
+// Depth는 댓글 깊이를 반환합니다. (최상위 댓글 = 1)
+func (c *Comment) Depth() int {
+	return PathDepth(c.Path)
+}
+
+// PathDepth는 Materialized Path의 깊이를 반환합니다.
+func PathDepth(path string) int {
+	return strings.Count(path, "/") - 1
+}
+
+// CommentPath는 부모 경로와 ID로 Materialized Path를 생성합니다.
+func CommentPath(parentPath string, id uint) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + strconv.FormatUint(uint64(id), 10) + "/"
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"net/netip"
+	"time"
+)
+
+// LoginMethod 로그인 수단
+type LoginMethod string
+
+const (
+	LoginMethodPassword     LoginMethod = "password"
+	LoginMethodTOTP         LoginMethod = "totp"          // 비밀번호/외부 로그인 후 2단계 인증 코드
+	LoginMethodRecoveryCode LoginMethod = "recovery_code" // 비밀번호/외부 로그인 후 복구 코드
+)
+
+// OIDCLoginMethod는 외부 IdP 로그인 수단입니다. (예: "oidc:google")
+func OIDCLoginMethod(provider string) LoginMethod {
+	return LoginMethod("oidc:" + provider)
+}
+
+// LoginEvent는 사용자의 로그인 시도 기록입니다. (성공/실패)
+// 없는 계정으로 시도한 실패는 사용자가 없으므로 기록하지 않습니다.
+type LoginEvent struct {
+	ID            uint        `gorm:"primaryKey"`
+	UserID        uint        `gorm:"not null;index:idx_login_event_user_created"`
+	Method        LoginMethod `gorm:"size:64;not null"`
+	Success       bool        `gorm:"not null"`
+	FailureReason string      `gorm:"size:50"`
+	IP            string      `gorm:"size:45"`
+	IPRange       string      `gorm:"size:50"` // 새 위치 판단용 (IPv4 /24, IPv6 /48)
+	UserAgent     string      `gorm:"size:255"`
+	DeviceName    string      `gorm:"size:100"`
+	NewDevice     bool        `gorm:"not null;default:false"` // 처음 보는 기기/위치에서 로그인 성공 (알림 발송)
+	CreatedAt     time.Time   `gorm:"index:idx_login_event_user_created"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (LoginEvent) TableName() string {
+	return "login_events"
+}
+
+// IPRange는 IP가 속한 대역을 반환합니다. (IPv4 /24, IPv6 /48)
+// 같은 통신사/회사 안에서 주소가 조금 바뀌는 것은 새 위치로 보지 않습니다.
+func IPRange(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
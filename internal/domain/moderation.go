@@ -0,0 +1,9 @@
+package domain
+
+// ModerationStatus는 게시글/댓글의 검토 상태를 나타냅니다.
+type ModerationStatus string
+
+const (
+	ModerationApproved ModerationStatus = "approved" // 공개
+	ModerationPending  ModerationStatus = "pending"  // 검토 대기 (목록에서 숨김)
+)
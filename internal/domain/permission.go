@@ -0,0 +1,70 @@
+package domain
+
+// Permission은 역할에 부여하는 세부 권한입니다. (리소스.동작[.범위])
+type Permission string
+
+const (
+	PermPostEditAny      Permission = "post.edit.any"      // 다른 사용자의 게시글 수정
+	PermPostDeleteAny    Permission = "post.delete.any"    // 다른 사용자의 게시글 삭제
+	PermPostLockAny      Permission = "post.lock.any"      // 다른 사용자 게시글의 댓글 잠금
+	PermPostModerate     Permission = "post.moderate"      // 검토 대기 게시글 승인/거부
+	PermCommentEditAny   Permission = "comment.edit.any"   // 다른 사용자의 댓글 수정
+	PermCommentDeleteAny Permission = "comment.delete.any" // 다른 사용자의 댓글 삭제
+	PermCommentModerate  Permission = "comment.moderate"   // 댓글 검토/복구/이동/병합/트리 삭제
+	PermUserRead         Permission = "user.read"          // 사용자 목록/정보 조회
+	PermUserBan          Permission = "user.ban"           // 사용자 이용 정지
+	PermUserLogout       Permission = "user.logout"        // 사용자 강제 로그아웃
+	PermUserDelete       Permission = "user.delete"        // 사용자 삭제
+	PermUserUnlock       Permission = "user.unlock"        // 로그인 잠금 해제
+	PermUserImpersonate  Permission = "user.impersonate"   // 다른 사용자로 대리 로그인
+	PermRoleManage       Permission = "role.manage"        // 역할 생성/수정/삭제
+	PermRoleAssign       Permission = "role.assign"        // 사용자 역할 변경
+	PermStatsRead        Permission = "stats.read"         // 통계 조회
+	PermAuditRead        Permission = "audit.read"         // 감사 로그 조회
+)
+
+// PermissionInfo 권한 목록 응답용
+type PermissionInfo struct {
+	Name        Permission `json:"name"`
+	Description string     `json:"description"`
+}
+
+// Permissions는 정의된 모든 권한입니다.
+var Permissions = []PermissionInfo{
+	{PermPostEditAny, "다른 사용자의 게시글 수정"},
+	{PermPostDeleteAny, "다른 사용자의 게시글 삭제"},
+	{PermPostLockAny, "다른 사용자 게시글의 댓글 잠금"},
+	{PermPostModerate, "검토 대기 게시글 승인/거부"},
+	{PermCommentEditAny, "다른 사용자의 댓글 수정"},
+	{PermCommentDeleteAny, "다른 사용자의 댓글 삭제"},
+	{PermCommentModerate, "검토 대기 댓글 승인/거부, 댓글 복구/이동/병합/트리 삭제"},
+	{PermUserRead, "사용자 조회"},
+	{PermUserBan, "사용자 이용 정지"},
+	{PermUserLogout, "사용자 강제 로그아웃 (모든 세션/토큰 폐기)"},
+	{PermUserDelete, "사용자 삭제"},
+	{PermUserUnlock, "로그인 잠금 해제"},
+	{PermUserImpersonate, "다른 사용자로 대리 로그인 (고객 지원)"},
+	{PermRoleManage, "역할 생성/수정/삭제"},
+	{PermRoleAssign, "사용자 역할 변경"},
+	{PermStatsRead, "통계 조회"},
+	{PermAuditRead, "감사 로그 조회"},
+}
+
+// IsValid 정의된 권한인지 확인
+func (p Permission) IsValid() bool {
+	for _, info := range Permissions {
+		if info.Name == p {
+			return true
+		}
+	}
+	return false
+}
+
+// AllPermissions는 정의된 모든 권한 이름을 반환합니다.
+func AllPermissions() []Permission {
+	perms := make([]Permission, len(Permissions))
+	for i, info := range Permissions {
+		perms[i] = info.Name
+	}
+	return perms
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// PersonalAccessToken은 스크립트/연동용으로 사용자가 발급한 장기 토큰입니다.
+//
+//	원문은 발급 응답에서 한 번만 보여주고 DB에는 SHA-256 해시만 저장합니다.
+//	Scopes에 포함된 권한만 사용할 수 있습니다.
+type PersonalAccessToken struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	TokenHash   string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	TokenPrefix string     `gorm:"size:16;not null" json:"token_prefix"` // 목록에서 토큰을 구분하기 위한 앞부분
+	Scopes      string     `gorm:"size:255;not null" json:"-"`           // 공백으로 구분
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                 // nil이면 만료 없음
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// ScopeList scope 목록
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// IsExpired 만료 여부
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
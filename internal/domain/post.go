@@ -7,12 +7,19 @@ import (
 )
 
 type Post struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Title     string         `gorm:"size:200;not null" json:"title"`
-	Content   string         `gorm:"type:text" json:"content"`
-	AuthorID  uint           `gorm:"not null;index" json:"author_id"`
-	Author    *User          `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
-	Views     int            `gorm:"default:0" json:"views"`
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	Title        string           `gorm:"size:200;not null" json:"title"`
+	Content      string           `gorm:"type:text" json:"content"`
+	AuthorID     uint             `gorm:"not null;index" json:"author_id"`
+	Author       *User            `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Views        int              `gorm:"default:0" json:"views"`
+	CommentCount int              `gorm:"not null;default:0" json:"comment_count"` // 비정규화 - 댓글 생성/삭제/복구 트랜잭션에서 함께 갱신한다.
+	Status       ModerationStatus `gorm:"size:20;default:approved;index" json:"status"`
+
+	// 댓글 잠금 - CommentsUnlockAt이 지나면 자동으로 해제된 것으로 본다.
+	CommentsLocked   bool       `gorm:"not null;default:false" json:"comments_locked"`
+	CommentsUnlockAt *time.Time `json:"comments_unlock_at,omitempty"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -21,3 +28,11 @@ type Post struct {
 func (Post) TableName() string {
 	return "posts"
 }
+
+// IsCommentsLocked는 현재 댓글 작성이 잠겨 있는지 확인합니다.
+func (p *Post) IsCommentsLocked(now time.Time) bool {
+	if !p.CommentsLocked {
+		return false
+	}
+	return p.CommentsUnlockAt == nil || now.Before(*p.CommentsUnlockAt)
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// RecoveryCode는 인증 앱을 쓸 수 없을 때 사용하는 2단계 인증 복구 코드입니다.
+// 코드 원문은 등록 시 한 번만 보여주고 DB에는 해시만 저장합니다.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
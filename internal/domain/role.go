@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// RoleDefinition은 DB에 저장된 역할과 역할에 부여된 권한입니다.
+//
+//	User.Role은 Name을 가리킵니다.
+//	System 역할(user, admin)은 삭제할 수 없고, admin은 항상 모든 권한을 가집니다.
+type RoleDefinition struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	Name        Role             `gorm:"uniqueIndex;size:20;not null" json:"name"`
+	Description string           `gorm:"size:255" json:"description"`
+	System      bool             `gorm:"not null;default:false" json:"system"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (RoleDefinition) TableName() string {
+	return "roles"
+}
+
+// RolePermission 역할-권한 매핑
+type RolePermission struct {
+	RoleID     uint       `gorm:"primaryKey"`
+	Permission Permission `gorm:"primaryKey;size:50"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// PermissionList 권한 목록
+func (r *RoleDefinition) PermissionList() []Permission {
+	perms := make([]Permission, len(r.Permissions))
+	for i, p := range r.Permissions {
+		perms[i] = p.Permission
+	}
+	slices.Sort(perms)
+	return perms
+}
+
+// SetPermissions 권한 목록 교체 (중복 제거)
+func (r *RoleDefinition) SetPermissions(perms []Permission) {
+	r.Permissions = make([]RolePermission, 0, len(perms))
+	seen := make(map[Permission]bool, len(perms))
+	for _, p := range perms {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		r.Permissions = append(r.Permissions, RolePermission{RoleID: r.ID, Permission: p})
+	}
+}
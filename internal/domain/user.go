@@ -15,17 +15,30 @@ const (
 	RoleAdmin Role = "admin"
 )
 
+// DeletedUsername은 탈퇴한 사용자의 게시글/댓글에 표시할 이름입니다.
+const DeletedUsername = "탈퇴한 사용자"
+
 // User는 사용자 엔티티입니다.
 type User struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Email       string         `gorm:"uniqueIndex;size:255;not null" json:"email"`
-	Password    string         `gorm:"size:255;not null" json:"-"` // JSON 응답에서 제외
-	Username    string         `gorm:"size:100;not null" json:"username"`
-	Role        Role           `gorm:"size:20;default:user" json:"role"`
-	LastLoginAt *time.Time     `json:"last_login_at,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Email           string         `gorm:"uniqueIndex;size:255;not null" json:"email"`
+	Password        string         `gorm:"size:255;not null" json:"-"` // JSON 응답에서 제외
+	Username        string         `gorm:"size:100;not null" json:"username"`
+	Role            Role           `gorm:"size:20;default:user" json:"role"`
+	LastLoginAt     *time.Time     `json:"last_login_at,omitempty"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"` // nil이면 미인증 (읽기 전용)
+	TOTPSecret      string         `gorm:"size:64" json:"-"`            // 등록 중이거나 등록된 TOTP 시크릿
+	TOTPEnabledAt   *time.Time     `json:"-"`                           // nil이면 2단계 인증 미사용
+	TOTPLastStep    int64          `json:"-"`                           // 마지막으로 사용한 TOTP 시간 단계 (재사용 방지)
+	SuspendedAt     *time.Time     `json:"suspended_at,omitempty"`      // nil이면 정상
+	SuspendedUntil  *time.Time     `json:"suspended_until,omitempty"`   // nil이면 영구 정지 (ban)
+	SuspendReason   string         `gorm:"size:255" json:"suspend_reason,omitempty"`
+	Bio             string         `gorm:"size:500" json:"bio"`
+	AvatarURL       string         `gorm:"size:500" json:"avatar_url"`
+	DeletionDueAt   *time.Time     `gorm:"index" json:"deletion_due_at,omitempty"` // 탈퇴 요청 시 설정, 이 시각이 지나면 익명화 후 삭제
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName은 테이블 이름을 반환합니다.
@@ -33,6 +46,29 @@ func (User) TableName() string {
 	return "users"
 }
 
+// IsEmailVerified 이메일 인증 여부
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsTwoFactorEnabled 2단계 인증 사용 여부
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// IsSuspended 이용 정지 여부 (기간이 지난 정지는 해제된 것으로 봄)
+func (u *User) IsSuspended(now time.Time) bool {
+	if u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil)
+}
+
+// IsDeletionScheduled 탈퇴 유예 기간 중인지 여부
+func (u *User) IsDeletionScheduled() bool {
+	return u.DeletionDueAt != nil
+}
+
 // BeforeCreate는 사용자 생성 전에 비밀번호를 해싱합니다. - 쓰지말것 :: 해싱된거 다시 해싱하거나, 유효성 검사 불가 -> 서비스 로직에 명시
 func (u *User) BeforeCreateDeprecated(tx *gorm.DB) error {
 	if u.Password != "" {
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// UserIdentity는 사용자와 연결된 외부 IdP 계정입니다.
+// (Provider, Subject) 쌍이 IdP 안에서 사용자를 고유하게 식별합니다.
+type UserIdentity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Provider  string    `gorm:"size:50;not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject   string    `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+	Email     string    `gorm:"size:255" json:"email"` // 연결 당시 IdP가 알려준 이메일
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
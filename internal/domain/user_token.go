@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// TokenPurpose 일회용 토큰 용도
+type TokenPurpose string
+
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// UserToken은 메일로 보내는 일회용 토큰입니다.
+//
+//	원문은 메일로만 전달하고 DB에는 SHA-256 해시만 저장합니다.
+//	한 번 사용(UsedAt)되거나 만료(ExpiresAt)되면 다시 쓸 수 없습니다.
+type UserToken struct {
+	ID        uint         `gorm:"primaryKey"`
+	UserID    uint         `gorm:"not null;index"`
+	Purpose   TokenPurpose `gorm:"size:30;not null"`
+	TokenHash string       `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time    `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName은 테이블 이름을 반환합니다.
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// IsUsable 사용 가능 여부 (미사용 + 만료 전)
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
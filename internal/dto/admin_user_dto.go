@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// 사용자 상태 필터
+const (
+	UserStatusActive     = "active"
+	UserStatusSuspended  = "suspended"
+	UserStatusUnverified = "unverified"
+)
+
+// UserSearchParams 관리자 사용자 검색 조건 (날짜는 YYYY-MM-DD, To는 해당 날짜 포함)
+type UserSearchParams struct {
+	Query         string     `form:"q"` // 이메일 또는 사용자 이름
+	Role          string     `form:"role"`
+	Status        string     `form:"status" binding:"omitempty,oneof=active suspended unverified"`
+	CreatedFrom   *time.Time `form:"created_from" time_format:"2006-01-02"`
+	CreatedTo     *time.Time `form:"created_to" time_format:"2006-01-02"`
+	LastLoginFrom *time.Time `form:"last_login_from" time_format:"2006-01-02"`
+	LastLoginTo   *time.Time `form:"last_login_to" time_format:"2006-01-02"`
+	Sort          string     `form:"sort"` // 예: "last_login_at,desc"
+}
+
+// 허용된 사용자 정렬 필드
+var allowedUserSortFields = map[string]bool{
+	"id":            true,
+	"email":         true,
+	"username":      true,
+	"created_at":    true,
+	"last_login_at": true,
+}
+
+// ToOrderString GORM Order 문자열 생성 (기본값: 가입일 최신순)
+func (p *UserSearchParams) ToOrderString() string {
+	field, dir, _ := strings.Cut(p.Sort, ",")
+	field = strings.ToLower(strings.TrimSpace(field))
+	if !allowedUserSortFields[field] {
+		return "created_at DESC, id DESC"
+	}
+
+	direction := "ASC"
+	if strings.EqualFold(strings.TrimSpace(dir), "desc") {
+		direction = "DESC"
+	}
+	// 로그인한 적 없는 사용자는 항상 뒤로
+	if field == "last_login_at" {
+		return field + " " + direction + " NULLS LAST, id " + direction
+	}
+	return field + " " + direction + ", id " + direction
+}
+
+// SuspendUserRequest 이용 정지 요청 - until이 없으면 영구 정지 (ban)
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" binding:"required,max=255"`
+	Until  *time.Time `json:"until"`
+}
+
+// AdminUserResponse 관리자용 사용자 정보
+type AdminUserResponse struct {
+	ID               uint       `json:"id"`
+	Email            string     `json:"email"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	Suspended        bool       `json:"suspended"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"`
+	SuspendReason    string     `json:"suspend_reason,omitempty"`
+	LastLoginAt      *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AdminUserDetailResponse 관리자용 사용자 상세 정보
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	Sessions           []SessionResponse `json:"sessions"`
+	PersonalTokenCount int               `json:"personal_token_count"`
+}
+
+// UserStatsResponse 사용자 통계
+type UserStatsResponse struct {
+	Total       int64            `json:"total"`
+	Suspended   int64            `json:"suspended"`
+	Unverified  int64            `json:"unverified"`
+	NewLastWeek int64            `json:"new_last_week"`
+	ByRole      map[string]int64 `json:"by_role"`
+}
+
+// ImpersonationResponse 대리 로그인 토큰 - Refresh Token 없이 짧게만 유효
+type ImpersonationResponse struct {
+	AccessToken    string             `json:"access_token"`
+	TokenType      string             `json:"token_type"`
+	ExpiresIn      int64              `json:"expires_in"` // 초 단위
+	User           *AdminUserResponse `json:"user"`
+	ImpersonatedBy uint               `json:"impersonated_by"`
+}
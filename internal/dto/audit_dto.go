@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditSearchParams 감사 로그 검색 조건 (날짜는 YYYY-MM-DD, To는 해당 날짜 포함)
+type AuditSearchParams struct {
+	Action    string     `form:"action"` // 정확히 일치하거나 접두사 (예: "auth.login"은 auth.login.*)
+	ActorID   *uint      `form:"actor_id"`
+	TargetID  *uint      `form:"target_id"`
+	IP        string     `form:"ip"`
+	RequestID string     `form:"request_id"`
+	From      *time.Time `form:"from" time_format:"2006-01-02"`
+	To        *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// AuditEventResponse 감사 로그 이벤트 (NDJSON 내보내기도 같은 형식)
+type AuditEventResponse struct {
+	ID        uint            `json:"id"`
+	Action    string          `json:"action"`
+	ActorID   *uint           `json:"actor_id"`
+	TargetID  *uint           `json:"target_id"`
+	IP        string          `json:"ip"`
+	UserAgent string          `json:"user_agent"`
+	RequestID string          `json:"request_id"`
+	Metadata  json.RawMessage `json:"metadata"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AuditVerifyResponse 해시 체인 검증 결과
+type AuditVerifyResponse struct {
+	Valid    bool   `json:"valid"`
+	Checked  int64  `json:"checked"`             // 검사한 이벤트 수
+	BrokenAt *uint  `json:"broken_at,omitempty"` // 체인이 처음 끊긴 이벤트 ID
+	LastHash string `json:"last_hash,omitempty"` // 마지막 이벤트 해시 - 외부에 보관해두면 끝부분 삭제도 확인 가능
+}
@@ -11,45 +11,145 @@ type SignupRequest struct {
 
 // SignupResponse는 회원가입 응답입니다.
 type SignupResponse struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uint      `json:"id"`
+	Email         string    `json:"email"`
+	Username      string    `json:"username"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // LoginRequest는 로그인 요청입니다.
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"` // 세션 목록에 표시할 기기 이름
 }
 
 // LoginResponse는 로그인 응답입니다.
+// 2단계 인증 사용자는 토큰 대신 ChallengeToken을 받고 /2fa/verify에서 교환합니다.
 type LoginResponse struct {
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
-	TokenType    string       `json:"token_type"`
-	ExpiresIn    int64        `json:"expires_in"` // 초 단위
-	User         UserResponse `json:"user"`
+	AccessToken       string        `json:"access_token,omitempty"`
+	RefreshToken      string        `json:"refresh_token,omitempty"`
+	TokenType         string        `json:"token_type,omitempty"`
+	ExpiresIn         int64         `json:"expires_in,omitempty"` // 초 단위
+	User              *UserResponse `json:"user,omitempty"`
+	TwoFactorRequired bool          `json:"two_factor_required,omitempty"`
+	ChallengeToken    string        `json:"challenge_token,omitempty"`
+	CSRFToken         string        `json:"csrf_token,omitempty"` // 쿠키 세션이면 토큰 대신 반환 (X-CSRF-Token 헤더로 보냄)
 }
 
 // UserResponse는 사용자 정보 응답입니다.
 type UserResponse struct {
-	ID       uint   `json:"id"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	Username      string `json:"username"`
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
-// RefreshRequest는 토큰 갱신 요청입니다.
+// RefreshRequest는 토큰 갱신 요청입니다. 쿠키 세션은 본문 없이 Refresh Token 쿠키를 사용합니다.
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 // RefreshResponse는 토큰 갱신 응답입니다.
 type RefreshResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"`
+	CSRFToken    string `json:"csrf_token,omitempty"` // 쿠키 세션이면 토큰 대신 반환
+}
+
+// VerifyEmailRequest는 이메일 인증 요청입니다.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationRequest는 인증 메일 재발송 요청입니다.
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPasswordRequest는 비밀번호 재설정 메일 요청입니다.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest는 비밀번호 재설정 요청입니다.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+// TwoFactorSetupResponse는 2단계 인증 등록 시작 응답입니다.
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`           // 직접 입력용
+	ProvisioningURI string `json:"provisioning_uri"` // QR 코드로 표시할 otpauth:// URI
+}
+
+// TwoFactorConfirmRequest는 2단계 인증 등록 확인 요청입니다.
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// TwoFactorConfirmResponse는 2단계 인증 등록 완료 응답입니다.
+// 복구 코드는 이 응답에서 한 번만 보여줍니다.
+type TwoFactorConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorVerifyRequest는 로그인 2단계 인증 요청입니다. code와 recovery_code 중 하나가 필요합니다.
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code" binding:"required_without=Code"`
+}
+
+// TwoFactorDisableRequest는 2단계 인증 해제 요청입니다.
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// SessionResponse는 로그인 세션(기기) 정보 응답입니다.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"` // 요청을 보낸 세션 여부
+}
+
+// RevokeSessionsResponse는 다른 세션 일괄 종료 응답입니다.
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// CreatePersonalTokenRequest는 Personal Access Token 발급 요청입니다.
+type CreatePersonalTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // 생략하면 만료 없음
+}
+
+// PersonalTokenResponse는 Personal Access Token 정보 응답입니다. (원문 제외)
+type PersonalTokenResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatePersonalTokenResponse는 Personal Access Token 발급 응답입니다.
+// 토큰 원문은 이 응답에서 한 번만 보여줍니다.
+type CreatePersonalTokenResponse struct {
+	PersonalTokenResponse
+	Token string `json:"token"`
 }
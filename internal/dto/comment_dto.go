@@ -14,6 +14,17 @@ type UpdateCommentRequest struct {
 	Content string `json:"content" binding:"required"`
 }
 
+// MoveCommentRequest 댓글 이동 요청 (parent_id가 없으면 post_id 게시글의 최상위 댓글로 이동)
+type MoveCommentRequest struct {
+	ParentID *uint `json:"parent_id,omitempty"`
+	PostID   *uint `json:"post_id,omitempty"`
+}
+
+// MergeCommentRequest 댓글 병합 요청
+type MergeCommentRequest struct {
+	TargetID uint `json:"target_id" binding:"required"`
+}
+
 // CommentResponse 댓글 응답
 type CommentResponse struct {
 	ID        uint               `json:"id"`
@@ -21,6 +32,7 @@ type CommentResponse struct {
 	ParentID  *uint              `json:"parent_id,omitempty"`
 	Content   string             `json:"content"`
 	Author    string             `json:"author"`
+	Status    string             `json:"status"` // approved, pending(검토 대기)
 	CreatedAt time.Time          `json:"created_at"`
 	Replies   []*CommentResponse `json:"replies,omitempty"`
 }
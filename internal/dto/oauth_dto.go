@@ -0,0 +1,38 @@
+package dto
+
+// TokenRequest 토큰 확인/폐기 요청 (application/x-www-form-urlencoded)
+type TokenRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"` // access_token 또는 refresh_token (틀려도 다른 종류로 다시 확인)
+}
+
+// IntrospectionResponse 토큰 확인 응답 (RFC 7662)
+// 사용할 수 없는 토큰이면 active: false만 반환합니다.
+type IntrospectionResponse struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`      // Personal Access Token의 scope (공백으로 구분)
+	Username  string   `json:"username,omitempty"`   // 사용자 이메일
+	TokenType string   `json:"token_type,omitempty"` // Access Token이면 Bearer
+	Exp       int64    `json:"exp,omitempty"`
+	Iat       int64    `json:"iat,omitempty"`
+	Nbf       int64    `json:"nbf,omitempty"`
+	Sub       string   `json:"sub,omitempty"`
+	Aud       []string `json:"aud,omitempty"`
+	Iss       string   `json:"iss,omitempty"`
+	Jti       string   `json:"jti,omitempty"`
+
+	// 확장 필드
+	TokenUse  string              `json:"token_use,omitempty"` // access, refresh, personal
+	UserID    uint                `json:"user_id,omitempty"`
+	Role      string              `json:"role,omitempty"`
+	SessionID string              `json:"sid,omitempty"`
+	TwoFactor bool                `json:"2fa,omitempty"`
+	Actor     *IntrospectionActor `json:"act,omitempty"` // 대리 로그인 토큰이면 관리자
+}
+
+// IntrospectionActor 대리 로그인한 관리자 (RFC 8693 act)
+type IntrospectionActor struct {
+	Sub    string `json:"sub"`
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
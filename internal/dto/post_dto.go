@@ -15,23 +15,34 @@ type UpdatePostRequest struct {
 	Content string `json:"content" binding:"required"`
 }
 
+// LockCommentsRequest 댓글 잠금 요청
+type LockCommentsRequest struct {
+	Locked   *bool      `json:"locked" binding:"required"`
+	UnlockAt *time.Time `json:"unlock_at,omitempty"` // 자동 해제 시각 (없으면 수동 해제 전까지 유지)
+}
+
 // PostResponse 게시글 응답
 type PostResponse struct {
-	ID        uint      `json:"id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	Author    string    `json:"author"`
-	Views     int       `json:"views"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint       `json:"id"`
+	Title            string     `json:"title"`
+	Content          string     `json:"content"`
+	Author           string     `json:"author"`
+	Views            int        `json:"views"`
+	CommentCount     int        `json:"comment_count"`
+	Status           string     `json:"status"` // approved, pending(검토 대기)
+	CommentsLocked   bool       `json:"comments_locked"`
+	CommentsUnlockAt *time.Time `json:"comments_unlock_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // PostListResponse 게시글 목록 응답
 type PostListResponse struct {
-	ID        uint      `json:"id"`
-	Title     string    `json:"title"`
-	Author    string    `json:"author"`
-	Views     int       `json:"views"`
-	CreatedAt time.Time `json:"created_at"`
-	Highlight string    `json:"highlight,omitempty"` // 검색어 주변 텍스트 - FE 구현을 용이하게 하기 위함
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	Author       string    `json:"author"`
+	Views        int       `json:"views"`
+	CommentCount int       `json:"comment_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	Highlight    string    `json:"highlight,omitempty"` // 검색어 주변 텍스트 - FE 구현을 용이하게 하기 위함
 }
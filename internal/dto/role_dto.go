@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CreateRoleRequest 역할 생성 요청
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=20"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateRoleRequest 역할 수정 요청 - 권한 목록은 통째로 교체
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// AssignRoleRequest 사용자 역할 변경 요청
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// RoleResponse 역할 응답
+type RoleResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	System      bool      `json:"system"`
+	Permissions []string  `json:"permissions"`
+	UserCount   int64     `json:"user_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -15,12 +15,13 @@ type SortItem struct {
 
 // 허용된 정렬 필드
 var allowedSortFields = map[string]bool{
-	"id":         true,
-	"title":      true,
-	"author":     true,
-	"views":      true,
-	"created_at": true,
-	"updated_at": true,
+	"id":            true,
+	"title":         true,
+	"author":        true,
+	"views":         true,
+	"comment_count": true,
+	"created_at":    true,
+	"updated_at":    true,
 }
 
 // Parse 정렬 문자열 파싱
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// ProfileResponse 내 프로필
+type ProfileResponse struct {
+	ID               uint       `json:"id"`
+	Email            string     `json:"email"`
+	Username         string     `json:"username"`
+	Bio              string     `json:"bio"`
+	AvatarURL        string     `json:"avatar_url"`
+	Role             string     `json:"role"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	DeletionDueAt    *time.Time `json:"deletion_due_at,omitempty"` // 탈퇴 유예 중이면 삭제 예정 시각
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// UpdateProfileRequest 프로필 수정 요청 - 보낸 필드만 수정 (빈 문자열이면 bio/avatar_url 삭제)
+type UpdateProfileRequest struct {
+	Username  *string `json:"username" binding:"omitempty,min=2,max=50"`
+	Bio       *string `json:"bio" binding:"omitempty,max=500"`
+	AvatarURL *string `json:"avatar_url" binding:"omitempty,max=500"`
+}
+
+// ChangePasswordRequest 비밀번호 변경 요청
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// DeleteAccountRequest 탈퇴 요청 - 비밀번호로 본인 확인
+// 비밀번호를 모르는 외부 IdP 사용자는 비워두고, 다시 로그인한 직후(5분 이내)에 요청합니다.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// AccountDeletionResponse 탈퇴 요청 응답
+type AccountDeletionResponse struct {
+	DeletionDueAt time.Time `json:"deletion_due_at"` // 이 시각 전에 로그인해서 취소할 수 있음
+}
+
+// LoginEventResponse 로그인 기록
+type LoginEventResponse struct {
+	ID            uint      `json:"id"`
+	Method        string    `json:"method"` // password, totp, recovery_code, oidc:{provider}
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	DeviceName    string    `json:"device_name,omitempty"`
+	NewDevice     bool      `json:"new_device"` // 처음 보는 기기/위치 (알림 발송)
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,139 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"gorm-test/internal/repository"
+	"sync"
+	"time"
+)
+
+// AccountAgeFilter는 가입한 지 얼마 안 된 계정의 작성 빈도를 제한합니다.
+//
+//	가입 후 minAge가 지나지 않은 계정은 window 동안 maxPosts개까지만 작성할 수 있습니다.
+type AccountAgeFilter struct {
+	userRepo repository.UserRepository
+	minAge   time.Duration
+	window   time.Duration
+	maxPosts int
+
+	mu      sync.Mutex
+	history map[uint][]time.Time // userID -> 최근 작성 시각
+}
+
+// NewAccountAgeFilter 생성자
+func NewAccountAgeFilter(userRepo repository.UserRepository, minAge, window time.Duration, maxPosts int) *AccountAgeFilter {
+	return &AccountAgeFilter{
+		userRepo: userRepo,
+		minAge:   minAge,
+		window:   window,
+		maxPosts: maxPosts,
+		history:  make(map[uint][]time.Time),
+	}
+}
+
+func (f *AccountAgeFilter) Name() string {
+	return "new_account_rate"
+}
+
+func (f *AccountAgeFilter) Check(ctx context.Context, content *Content) (Result, error) {
+	// 게시글만 제한 (댓글은 제외)
+	if content.Target != TargetPost || content.UserID == 0 {
+		return Allowed(f.Name()), nil
+	}
+
+	isNew, err := f.isNewAccount(ctx, content.UserID)
+	if err != nil {
+		return Result{}, err
+	}
+	if !isNew {
+		return Allowed(f.Name()), nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.check(content.UserID), nil
+}
+
+// Reserve는 신규 계정의 작성 시각을 기록합니다.
+// 검사와 기록을 같은 잠금 안에서 하므로 동시 요청이 함께 한도를 넘지 않습니다.
+func (f *AccountAgeFilter) Reserve(ctx context.Context, content *Content) (Result, func(), error) {
+	if content.Target != TargetPost || content.UserID == 0 {
+		return Allowed(f.Name()), func() {}, nil
+	}
+
+	isNew, err := f.isNewAccount(ctx, content.UserID)
+	if err != nil {
+		return Result{}, nil, err
+	}
+	if !isNew {
+		return Allowed(f.Name()), func() {}, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if result := f.check(content.UserID); result.Decision == DecisionReject {
+		return result, nil, nil
+	}
+
+	stamp := time.Now()
+	f.history[content.UserID] = append(f.prune(content.UserID), stamp)
+
+	return Allowed(f.Name()), func() { f.remove(content.UserID, stamp) }, nil
+}
+
+// check는 window 안의 작성 수를 확인합니다. (호출 시 lock 필요)
+func (f *AccountAgeFilter) check(userID uint) Result {
+	if len(f.prune(userID)) >= f.maxPosts {
+		return Result{
+			Decision: DecisionReject,
+			Reason:   fmt.Sprintf("신규 계정은 %s 동안 %d개까지만 작성할 수 있습니다", f.window, f.maxPosts),
+		}
+	}
+	return Allowed(f.Name())
+}
+
+// remove는 저장에 실패한 작성 기록을 지웁니다.
+func (f *AccountAgeFilter) remove(userID uint, stamp time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stamps := f.history[userID]
+	for i, t := range stamps {
+		if t.Equal(stamp) {
+			f.history[userID] = append(stamps[:i], stamps[i+1:]...)
+			break
+		}
+	}
+	f.prune(userID)
+}
+
+func (f *AccountAgeFilter) isNewAccount(ctx context.Context, userID uint) (bool, error) {
+	user, err := f.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return time.Since(user.CreatedAt) < f.minAge, nil
+}
+
+// prune은 window가 지난 기록을 정리합니다. (호출 시 lock 필요)
+func (f *AccountAgeFilter) prune(userID uint) []time.Time {
+	cutoff := time.Now().Add(-f.window)
+	stamps := f.history[userID]
+
+	kept := stamps[:0]
+	for _, t := range stamps {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(f.history, userID)
+		return nil
+	}
+	f.history[userID] = kept
+	return kept
+}
@@ -0,0 +1,65 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BlocklistFilter는 금칙어/정규식 목록으로 콘텐츠를 검사합니다.
+//
+//	키워드는 대소문자를 구분하지 않는 부분 일치로 검사합니다.
+type BlocklistFilter struct {
+	name     string
+	decision Decision // 일치 시 내릴 판정 (reject 또는 hold)
+	keywords []string
+	patterns []*regexp.Regexp
+}
+
+// NewBlocklistFilter 생성자 - 정규식 컴파일 실패 시 에러를 반환합니다.
+func NewBlocklistFilter(name string, decision Decision, keywords, patterns []string) (*BlocklistFilter, error) {
+	f := &BlocklistFilter{
+		name:     name,
+		decision: decision,
+	}
+
+	for _, k := range keywords {
+		k = strings.TrimSpace(strings.ToLower(k))
+		if k != "" {
+			f.keywords = append(f.keywords, k)
+		}
+	}
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 잘못된 정규식 %q: %w", name, p, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+
+	return f, nil
+}
+
+func (f *BlocklistFilter) Name() string {
+	return f.name
+}
+
+func (f *BlocklistFilter) Check(ctx context.Context, content *Content) (Result, error) {
+	text := strings.ToLower(content.Text)
+
+	for _, k := range f.keywords {
+		if strings.Contains(text, k) {
+			return Result{Decision: f.decision, Reason: "허용되지 않는 단어가 포함되어 있습니다"}, nil
+		}
+	}
+
+	for _, re := range f.patterns {
+		if re.MatchString(content.Text) {
+			return Result{Decision: f.decision, Reason: "허용되지 않는 표현이 포함되어 있습니다"}, nil
+		}
+	}
+
+	return Allowed(f.name), nil
+}
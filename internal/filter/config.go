@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"gorm-test/internal/config"
+	"gorm-test/internal/repository"
+)
+
+// NewPipelineFromConfig는 설정에 따라 필터 파이프라인을 구성합니다.
+// 필터가 비활성화되어 있으면 nil을 반환합니다. (nil 파이프라인은 항상 통과)
+func NewPipelineFromConfig(cfg config.ContentFilterConfig, userRepo repository.UserRepository) (*Pipeline, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var filters []Filter
+
+	// 1. 금칙어 (거부)
+	if len(cfg.BlockedKeywords) > 0 || len(cfg.BlockedPatterns) > 0 {
+		f, err := NewBlocklistFilter("blocklist", DecisionReject, cfg.BlockedKeywords, cfg.BlockedPatterns)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	// 2. 검토 대상 단어 (보류)
+	if len(cfg.ReviewKeywords) > 0 || len(cfg.ReviewPatterns) > 0 {
+		f, err := NewBlocklistFilter("review_list", DecisionHold, cfg.ReviewKeywords, cfg.ReviewPatterns)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	// 3. 링크 수
+	if cfg.MaxLinksHold > 0 || cfg.MaxLinksReject > 0 {
+		filters = append(filters, NewLinkFilter(cfg.MaxLinksHold, cfg.MaxLinksReject))
+	}
+
+	// 4. 반복 작성
+	if cfg.DuplicateWindow > 0 {
+		filters = append(filters, NewDuplicateFilter(cfg.DuplicateWindow, cfg.DuplicateMaxRepeats))
+	}
+
+	// 5. 신규 계정 작성 빈도
+	if cfg.NewAccountAge > 0 && cfg.NewAccountMaxPosts > 0 && userRepo != nil {
+		filters = append(filters, NewAccountAgeFilter(userRepo, cfg.NewAccountAge, cfg.NewAccountWindow, cfg.NewAccountMaxPosts))
+	}
+
+	return NewPipeline(filters...), nil
+}
@@ -0,0 +1,133 @@
+package filter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DuplicateFilter는 같은 사용자가 같은 내용을 반복해서 올리는 것을 막습니다.
+//
+//	window 안에 같은 내용이 maxRepeats번 이상 기록되어 있으면 거부합니다.
+//	단일 인스턴스 메모리 저장소 - 여러 대로 운영하면 Redis로 옮겨야 합니다.
+type DuplicateFilter struct {
+	window     time.Duration
+	maxRepeats int
+
+	mu      sync.Mutex
+	history map[uint][]contentStamp // userID -> 최근 작성 내역
+}
+
+type contentStamp struct {
+	hash string
+	at   time.Time
+}
+
+// NewDuplicateFilter 생성자
+func NewDuplicateFilter(window time.Duration, maxRepeats int) *DuplicateFilter {
+	if maxRepeats < 1 {
+		maxRepeats = 1
+	}
+	return &DuplicateFilter{
+		window:     window,
+		maxRepeats: maxRepeats,
+		history:    make(map[uint][]contentStamp),
+	}
+}
+
+func (f *DuplicateFilter) Name() string {
+	return "duplicate_content"
+}
+
+func (f *DuplicateFilter) Check(ctx context.Context, content *Content) (Result, error) {
+	if content.UserID == 0 {
+		return Allowed(f.Name()), nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.check(content.UserID, contentHash(content.Text)), nil
+}
+
+// Reserve는 통과된 콘텐츠를 작성 내역에 추가합니다.
+// 검사와 기록을 같은 잠금 안에서 하므로 같은 내용의 동시 요청이 함께 통과하지 않습니다.
+func (f *DuplicateFilter) Reserve(ctx context.Context, content *Content) (Result, func(), error) {
+	if content.UserID == 0 {
+		return Allowed(f.Name()), func() {}, nil
+	}
+
+	hash := contentHash(content.Text)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if result := f.check(content.UserID, hash); result.Decision == DecisionReject {
+		return result, nil, nil
+	}
+
+	stamp := contentStamp{hash: hash, at: time.Now()}
+	f.history[content.UserID] = append(f.prune(content.UserID), stamp)
+
+	return Allowed(f.Name()), func() { f.remove(content.UserID, stamp) }, nil
+}
+
+// check는 window 안의 같은 내용 수를 확인합니다. (호출 시 lock 필요)
+func (f *DuplicateFilter) check(userID uint, hash string) Result {
+	repeats := 0
+	for _, s := range f.prune(userID) {
+		if s.hash == hash {
+			repeats++
+		}
+	}
+
+	if repeats >= f.maxRepeats {
+		return Result{Decision: DecisionReject, Reason: "같은 내용을 반복해서 작성할 수 없습니다"}
+	}
+	return Allowed(f.Name())
+}
+
+// remove는 저장에 실패한 작성 내역을 지웁니다.
+func (f *DuplicateFilter) remove(userID uint, stamp contentStamp) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stamps := f.history[userID]
+	for i, s := range stamps {
+		if s == stamp {
+			f.history[userID] = append(stamps[:i], stamps[i+1:]...)
+			break
+		}
+	}
+	f.prune(userID)
+}
+
+// prune은 window가 지난 내역을 정리합니다. (호출 시 lock 필요)
+func (f *DuplicateFilter) prune(userID uint) []contentStamp {
+	cutoff := time.Now().Add(-f.window)
+	stamps := f.history[userID]
+
+	kept := stamps[:0]
+	for _, s := range stamps {
+		if s.at.After(cutoff) {
+			kept = append(kept, s)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(f.history, userID)
+		return nil
+	}
+	f.history[userID] = kept
+	return kept
+}
+
+// contentHash는 공백/대소문자를 정규화한 내용의 해시를 반환합니다.
+func contentHash(text string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	h := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(h[:])
+}
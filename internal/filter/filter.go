@@ -0,0 +1,177 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"gorm-test/pkg/apperror"
+	"gorm-test/pkg/metrics"
+	"log/slog"
+	"net/http"
+)
+
+var (
+	ErrContentRejected = errors.New("content rejected by filter")
+)
+
+// Decision은 필터의 판정 결과입니다.
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"  // 통과
+	DecisionHold   Decision = "hold"   // 검토 대기
+	DecisionReject Decision = "reject" // 거부
+)
+
+// severity는 판정의 우선순위입니다. (reject > hold > allow)
+func (d Decision) severity() int {
+	switch d {
+	case DecisionReject:
+		return 2
+	case DecisionHold:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Target은 검사 대상 종류입니다.
+type Target string
+
+const (
+	TargetPost    Target = "post"
+	TargetComment Target = "comment"
+)
+
+// Content는 필터 검사 대상입니다.
+type Content struct {
+	Target Target
+	UserID uint
+	Text   string // 제목과 본문을 합친 텍스트
+}
+
+// Result는 필터 실행 결과입니다.
+type Result struct {
+	Decision Decision
+	Filter   string // 판정을 내린 필터 이름
+	Reason   string // 사용자에게 보여줄 사유
+
+	releases []func() // 저장 실패 시 되돌릴 작성 기록
+}
+
+// Release는 Run이 남긴 작성 기록을 되돌립니다.
+// 판정 후 콘텐츠 저장에 실패했을 때 호출합니다. (실패한 작성은 집계하지 않음)
+func (r Result) Release() {
+	for _, release := range r.releases {
+		release()
+	}
+}
+
+// Allowed는 통과 결과를 생성합니다.
+func Allowed(filter string) Result {
+	return Result{Decision: DecisionAllow, Filter: filter}
+}
+
+// RejectError는 거부 판정을 API 에러로 변환합니다.
+func RejectError(result Result) *apperror.AppError {
+	return apperror.WrapWithStatus(
+		ErrContentRejected,
+		http.StatusUnprocessableEntity,
+		"CONTENT_REJECTED",
+		result.Reason,
+	).WithDetail("filter: " + result.Filter)
+}
+
+// Filter는 콘텐츠 필터 인터페이스입니다.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, content *Content) (Result, error)
+}
+
+// Recorder는 최종 판정 후 상태를 기록해야 하는 필터가 구현합니다.
+// (중복 검사, 작성 빈도 검사 등 - 거부된 콘텐츠는 기록하지 않음)
+//
+//	Reserve는 Check와 같은 조건을 잠금 안에서 다시 확인하고 기록합니다.
+//	그 사이 동시 요청이 먼저 기록했으면 거부 결과를 반환하고, 기록했으면 되돌리는 함수를 반환합니다.
+type Recorder interface {
+	Reserve(ctx context.Context, content *Content) (Result, func(), error)
+}
+
+// Pipeline은 여러 필터를 순서대로 실행합니다.
+//
+//	reject가 나오면 즉시 중단하고, hold는 기억해 두었다가 마지막에 반환합니다.
+type Pipeline struct {
+	filters []Filter
+}
+
+// NewPipeline 생성자
+func NewPipeline(filters ...Filter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// Run은 모든 필터를 실행하고 최종 판정을 반환합니다.
+// 파이프라인이 nil이면 항상 통과합니다.
+func (p *Pipeline) Run(ctx context.Context, content *Content) (Result, error) {
+	final := Allowed("pipeline")
+	if p == nil {
+		return final, nil
+	}
+
+	for _, f := range p.filters {
+		result, err := f.Check(ctx, content)
+		if err != nil {
+			return Result{}, err
+		}
+		if result.Filter == "" {
+			result.Filter = f.Name()
+		}
+
+		// 모든 판정을 기록
+		p.observe(ctx, content, result)
+
+		if result.Decision.severity() > final.Decision.severity() {
+			final = result
+		}
+		if final.Decision == DecisionReject {
+			return final, nil
+		}
+	}
+
+	// 통과 또는 보류된 콘텐츠만 상태 기록 - 저장에 실패하면 호출한 쪽에서 Release
+	for _, f := range p.filters {
+		r, ok := f.(Recorder)
+		if !ok {
+			continue
+		}
+
+		result, release, err := r.Reserve(ctx, content)
+		if err != nil {
+			final.Release()
+			return Result{}, err
+		}
+		if result.Decision == DecisionReject {
+			final.Release()
+			if result.Filter == "" {
+				result.Filter = f.Name()
+			}
+			p.observe(ctx, content, result)
+			return result, nil
+		}
+		final.releases = append(final.releases, release)
+	}
+
+	return final, nil
+}
+
+// observe는 판정을 메트릭과 로그로 남깁니다.
+func (p *Pipeline) observe(ctx context.Context, content *Content, result Result) {
+	metrics.ContentFilterDecisions.
+		WithLabelValues(string(content.Target), result.Filter, string(result.Decision)).
+		Inc()
+	slog.InfoContext(ctx, "content filter decision",
+		"target", content.Target,
+		"user_id", content.UserID,
+		"filter", result.Filter,
+		"decision", result.Decision,
+		"reason", result.Reason,
+	)
+}
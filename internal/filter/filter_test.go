@@ -0,0 +1,246 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm-test/internal/domain"
+	"gorm-test/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlocklist(t *testing.T, name string, decision Decision, keywords ...string) *BlocklistFilter {
+	t.Helper()
+	f, err := NewBlocklistFilter(name, decision, keywords, nil)
+	require.NoError(t, err)
+	return f
+}
+
+func post(userID uint, text string) *Content {
+	return &Content{Target: TargetPost, UserID: userID, Text: text}
+}
+
+func TestPipeline_NilAllowsEverything(t *testing.T) {
+	var p *Pipeline
+
+	result, err := p.Run(context.Background(), post(1, "anything"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, result.Decision)
+	result.Release() // 기록이 없어도 안전
+}
+
+func TestPipeline_MostSevereDecisionWins(t *testing.T) {
+	p := NewPipeline(
+		newBlocklist(t, "review_list", DecisionHold, "casino"),
+		newBlocklist(t, "blocklist", DecisionReject, "spam"),
+		NewLinkFilter(1, 3),
+	)
+	ctx := context.Background()
+
+	result, err := p.Run(ctx, post(1, "hello"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, result.Decision)
+
+	result, err = p.Run(ctx, post(1, "casino night"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionHold, result.Decision)
+	assert.Equal(t, "review_list", result.Filter)
+
+	// hold 뒤에 reject가 나오면 reject
+	result, err = p.Run(ctx, post(1, "casino SPAM"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReject, result.Decision)
+	assert.Equal(t, "blocklist", result.Filter)
+	assert.NotEmpty(t, result.Reason)
+}
+
+func TestPipeline_StopsAtFirstReject(t *testing.T) {
+	after := &countingFilter{}
+	p := NewPipeline(newBlocklist(t, "blocklist", DecisionReject, "spam"), after)
+
+	result, err := p.Run(context.Background(), post(1, "spam"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReject, result.Decision)
+	assert.Zero(t, after.checks)
+}
+
+func TestPipeline_CheckErrorAborts(t *testing.T) {
+	boom := errors.New("boom")
+	p := NewPipeline(&countingFilter{err: boom})
+
+	_, err := p.Run(context.Background(), post(1, "hello"))
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestLinkFilter(t *testing.T) {
+	f := NewLinkFilter(1, 2)
+	ctx := context.Background()
+
+	cases := []struct {
+		text string
+		want Decision
+	}{
+		{"no links", DecisionAllow},
+		{"see https://a.example", DecisionAllow},
+		{"https://a.example www.b.example", DecisionHold},
+		{"https://a.example http://b.example www.c.example", DecisionReject},
+	}
+	for _, tc := range cases {
+		result, err := f.Check(ctx, post(1, tc.text))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, result.Decision, tc.text)
+	}
+}
+
+func TestDuplicateFilter_RejectsRepeatsAfterRecording(t *testing.T) {
+	p := NewPipeline(NewDuplicateFilter(time.Hour, 1))
+	ctx := context.Background()
+
+	result, err := p.Run(ctx, post(1, "Hello   World"))
+	require.NoError(t, err)
+	require.Equal(t, DecisionAllow, result.Decision)
+
+	// 공백/대소문자만 다른 같은 내용
+	result, err = p.Run(ctx, post(1, "hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReject, result.Decision)
+	assert.Equal(t, "duplicate_content", result.Filter)
+
+	// 다른 사용자는 영향 없음
+	result, err = p.Run(ctx, post(2, "hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, result.Decision)
+}
+
+func TestDuplicateFilter_ReleaseUndoesRecord(t *testing.T) {
+	p := NewPipeline(NewDuplicateFilter(time.Hour, 1))
+	ctx := context.Background()
+
+	// 저장에 실패한 작성은 집계하지 않음
+	result, err := p.Run(ctx, post(1, "hello"))
+	require.NoError(t, err)
+	result.Release()
+
+	result, err = p.Run(ctx, post(1, "hello"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, result.Decision)
+}
+
+func TestDuplicateFilter_RejectedContentIsNotRecorded(t *testing.T) {
+	p := NewPipeline(
+		NewLinkFilter(0, 1),
+		NewDuplicateFilter(time.Hour, 1),
+	)
+	ctx := context.Background()
+
+	text := "https://a.example https://b.example"
+	result, err := p.Run(ctx, post(1, text))
+	require.NoError(t, err)
+	require.Equal(t, DecisionReject, result.Decision)
+	require.Equal(t, "link_count", result.Filter)
+
+	// 앞의 거부가 기록되었다면 duplicate_content로 거부됨
+	result, err = p.Run(ctx, post(1, text))
+	require.NoError(t, err)
+	assert.Equal(t, "link_count", result.Filter)
+}
+
+func TestDuplicateFilter_ConcurrentIdenticalContent(t *testing.T) {
+	p := NewPipeline(NewDuplicateFilter(time.Hour, 1))
+	ctx := context.Background()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := p.Run(ctx, post(1, "same text"))
+			if assert.NoError(t, err) && result.Decision == DecisionAllow {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, allowed)
+}
+
+func TestAccountAgeFilter(t *testing.T) {
+	users := &stubUserRepository{users: map[uint]*domain.User{
+		1: {ID: 1, CreatedAt: time.Now()},                           // 신규 계정
+		2: {ID: 2, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}, // 오래된 계정
+	}}
+	p := NewPipeline(NewAccountAgeFilter(users, 24*time.Hour, time.Hour, 2))
+	ctx := context.Background()
+
+	first, err := p.Run(ctx, post(1, "one"))
+	require.NoError(t, err)
+	require.Equal(t, DecisionAllow, first.Decision)
+
+	second, err := p.Run(ctx, post(1, "two"))
+	require.NoError(t, err)
+	require.Equal(t, DecisionAllow, second.Decision)
+
+	third, err := p.Run(ctx, post(1, "three"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionReject, third.Decision)
+	assert.Equal(t, "new_account_rate", third.Filter)
+
+	// 저장에 실패한 작성을 되돌리면 다시 작성 가능
+	second.Release()
+	third, err = p.Run(ctx, post(1, "three"))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, third.Decision)
+
+	// 댓글과 오래된 계정은 제한하지 않음
+	comment, err := p.Run(ctx, &Content{Target: TargetComment, UserID: 1, Text: "reply"})
+	require.NoError(t, err)
+	assert.Equal(t, DecisionAllow, comment.Decision)
+
+	for range 3 {
+		result, err := p.Run(ctx, post(2, "old account"))
+		require.NoError(t, err)
+		assert.Equal(t, DecisionAllow, result.Decision)
+	}
+}
+
+// countingFilter는 호출 횟수를 세는 필터입니다.
+type countingFilter struct {
+	checks int
+	err    error
+}
+
+func (f *countingFilter) Name() string { return "counting" }
+
+func (f *countingFilter) Check(ctx context.Context, content *Content) (Result, error) {
+	f.checks++
+	if f.err != nil {
+		return Result{}, f.err
+	}
+	return Allowed(f.Name()), nil
+}
+
+// stubUserRepository는 FindByID만 구현합니다.
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[uint]*domain.User
+}
+
+func (r *stubUserRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return user, nil
+}
@@ -0,0 +1,48 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// LinkFilter는 본문에 포함된 링크 수를 제한합니다.
+//
+//	holdOver 초과 시 검토 대기, rejectOver 초과 시 거부 (0이면 비활성화)
+type LinkFilter struct {
+	holdOver   int
+	rejectOver int
+}
+
+// NewLinkFilter 생성자
+func NewLinkFilter(holdOver, rejectOver int) *LinkFilter {
+	return &LinkFilter{
+		holdOver:   holdOver,
+		rejectOver: rejectOver,
+	}
+}
+
+func (f *LinkFilter) Name() string {
+	return "link_count"
+}
+
+func (f *LinkFilter) Check(ctx context.Context, content *Content) (Result, error) {
+	count := len(linkPattern.FindAllStringIndex(content.Text, -1))
+
+	if f.rejectOver > 0 && count > f.rejectOver {
+		return Result{
+			Decision: DecisionReject,
+			Reason:   fmt.Sprintf("링크는 최대 %d개까지 포함할 수 있습니다", f.rejectOver),
+		}, nil
+	}
+	if f.holdOver > 0 && count > f.holdOver {
+		return Result{
+			Decision: DecisionHold,
+			Reason:   "링크가 많아 관리자 검토 후 게시됩니다",
+		}, nil
+	}
+
+	return Allowed(f.Name()), nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"gorm-test/internal/dto"
+	"gorm-test/internal/service"
+	"gorm-test/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUserHandler 관리자 사용자 관리
+type AdminUserHandler struct {
+	adminUserService *service.AdminUserService
+}
+
+func NewAdminUserHandler(adminUserService *service.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{adminUserService: adminUserService}
+}
+
+// List 사용자 검색
+// GET /api/v1/admin/users?q=&role=&status=&created_from=&created_to=&last_login_from=&last_login_to=&sort=&page=&size=
+func (h *AdminUserHandler) List(c *gin.Context) {
+	var params dto.UserSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	pagination := dto.NewPagination(page, size, 20, 100)
+
+	users, meta, err := h.adminUserService.List(c.Request.Context(), &params, pagination)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(users, meta))
+}
+
+// Get 사용자 상세
+// GET /api/v1/admin/users/:id
+func (h *AdminUserHandler) Get(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUserService.Get(c.Request.Context(), id)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(user))
+}
+
+// Suspend 이용 정지 (until이 없으면 영구 정지)
+// POST /api/v1/admin/users/:id/suspend
+func (h *AdminUserHandler) Suspend(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	user, err := h.adminUserService.Suspend(c.Request.Context(), id, &req)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(user))
+}
+
+// Unsuspend 이용 정지 해제
+// DELETE /api/v1/admin/users/:id/suspend
+func (h *AdminUserHandler) Unsuspend(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUserService.Unsuspend(c.Request.Context(), id)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(user))
+}
+
+// ForceLogout 강제 로그아웃 (모든 세션과 Personal Access Token 폐기)
+// POST /api/v1/admin/users/:id/logout
+func (h *AdminUserHandler) ForceLogout(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUserService.ForceLogout(c.Request.Context(), id); err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Delete 사용자 삭제 (soft delete)
+// DELETE /api/v1/admin/users/:id
+func (h *AdminUserHandler) Delete(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUserService.Delete(c.Request.Context(), id); err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Impersonate 대리 로그인 토큰 발급 (고객 지원)
+// POST /api/v1/admin/users/:id/impersonate
+func (h *AdminUserHandler) Impersonate(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.adminUserService.Impersonate(c.Request.Context(), id)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// Stats 사용자 통계
+// GET /api/v1/admin/stats
+func (h *AdminUserHandler) Stats(c *gin.Context) {
+	stats, err := h.adminUserService.Stats(c.Request.Context())
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(stats))
+}
+
+// userIDParam은 경로의 사용자 ID를 읽습니다. 잘못된 ID면 400을 응답하고 false를 반환합니다.
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		writeProblem(c, apperror.BadRequest("유효하지 않은 사용자 ID입니다"))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/service"
+	"gorm-test/pkg/apperror"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler 감사 로그 조회
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// List 감사 로그 검색 (최신순)
+// GET /api/v1/admin/audit-events?action=&actor_id=&target_id=&ip=&request_id=&from=&to=&page=&size=
+func (h *AuditHandler) List(c *gin.Context) {
+	var params dto.AuditSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "50"))
+	pagination := dto.NewPagination(page, size, 50, 200)
+
+	events, meta, err := h.auditService.List(c.Request.Context(), &params, pagination)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(events, meta))
+}
+
+// Export 감사 로그 NDJSON 내보내기 (오래된 순, 검색 조건은 List와 같음)
+// GET /api/v1/admin/audit-events/export
+func (h *AuditHandler) Export(c *gin.Context) {
+	var params dto.AuditSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	filename := fmt.Sprintf("audit-events-%s.ndjson", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	// 이미 응답을 보내기 시작했으므로 중간에 실패하면 로그만 남김
+	encoder := json.NewEncoder(c.Writer)
+	err := h.auditService.Export(c.Request.Context(), &params, func(event *dto.AuditEventResponse) error {
+		return encoder.Encode(event)
+	})
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "audit export failed", "error", err)
+	}
+}
+
+// Verify 해시 체인 검증
+// GET /api/v1/admin/audit-events/verify
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.auditService.Verify(c.Request.Context())
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(result))
+}
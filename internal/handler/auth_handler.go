@@ -6,20 +6,25 @@ import (
 	"gorm-test/internal/dto"
 	"gorm-test/internal/service"
 	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+	"math"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
 	authService  service.AuthService
-	tokenService auth.TokenService
+	tokenService *auth.TokenService
+	cookies      middleware.SessionCookies
 }
 
-func NewAuthHandler(authService service.AuthService) *AuthHandler {
+func NewAuthHandler(authService service.AuthService, tokenService *auth.TokenService, cookies middleware.SessionCookies) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:  authService,
+		tokenService: tokenService,
+		cookies:      cookies,
 	}
 }
 
@@ -45,36 +50,120 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 	c.JSON(http.StatusCreated, resp)
 }
 
+// writeSuspended는 이용 정지된 계정의 로그인 거부 응답을 씁니다.
+func writeSuspended(c *gin.Context, err *service.SuspendedError) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":           "이용이 정지된 계정입니다",
+		"code":            "ACCOUNT_SUSPENDED",
+		"reason":          err.Reason,
+		"suspended_until": err.Until, // null이면 영구 정지
+	})
+}
+
 func (h *AuthHandler) handleError(c *gin.Context, err error) {
+	var lockedErr *auth.LockedError
+	var suspendedErr *service.SuspendedError
+	var appErr *apperror.AppError
+
 	switch {
+	case errors.As(err, &suspendedErr):
+		writeSuspended(c, suspendedErr)
+
+	case errors.As(err, &appErr):
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"error":  appErr.Message,
+			"code":   appErr.Code,
+			"fields": appErr.Fields,
+		})
+
+	case errors.As(err, &lockedErr):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		if lockedErr.Scope == auth.LockScopeIP {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "로그인 시도가 너무 많습니다. 잠시 후 다시 시도해주세요",
+				"code":  "TOO_MANY_LOGIN_ATTEMPTS",
+			})
+			return
+		}
+		c.JSON(http.StatusLocked, gin.H{
+			"error": "로그인 실패가 반복되어 계정이 일시적으로 잠겼습니다. 잠시 후 다시 시도해주세요",
+			"code":  "ACCOUNT_LOCKED",
+		})
+
 	case errors.Is(err, service.ErrEmailAlreadyExists):
 		c.JSON(http.StatusConflict, gin.H{
 			"error": "이미 사용 중인 이메일입니다",
 		})
 
-	case errors.Is(err, auth.ErrPasswordTooShort):
+	case errors.Is(err, service.ErrInvalidVerificationToken):
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "비밀번호는 8자 이상이어야 합니다",
+			"error": "유효하지 않거나 만료된 인증 토큰입니다",
+			"code":  "INVALID_VERIFICATION_TOKEN",
 		})
 
-	case errors.Is(err, auth.ErrPasswordNoUpper):
+	case errors.Is(err, service.ErrInvalidResetToken):
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "비밀번호에 대문자가 포함되어야 합니다",
+			"error": "유효하지 않거나 만료된 재설정 토큰입니다",
+			"code":  "INVALID_RESET_TOKEN",
 		})
 
-	case errors.Is(err, auth.ErrPasswordNoLower):
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "비밀번호에 소문자가 포함되어야 합니다",
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 2단계 인증을 사용 중입니다",
 		})
 
-	case errors.Is(err, auth.ErrPasswordNoDigit):
+	case errors.Is(err, service.ErrTwoFactorNotSetup), errors.Is(err, service.ErrTwoFactorNotEnabled):
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "비밀번호에 숫자가 포함되어야 합니다",
+			"error": "2단계 인증이 등록되어 있지 않습니다",
 		})
 
-	case errors.Is(err, auth.ErrPasswordNoSpecial):
+	case errors.Is(err, service.ErrTwoFactorRequired):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "관리자 계정은 2단계 인증을 해제할 수 없습니다",
+			"code":  "TWO_FACTOR_REQUIRED",
+		})
+
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "인증 코드가 올바르지 않습니다",
+			"code":  "INVALID_TWO_FACTOR_CODE",
+		})
+
+	case errors.Is(err, service.ErrInvalidTwoFactorChallenge):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "인증 시간이 지났습니다. 다시 로그인해주세요",
+			"code":  "INVALID_TWO_FACTOR_CHALLENGE",
+		})
+
+	case errors.Is(err, service.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "비밀번호가 올바르지 않습니다",
+		})
+
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+
+	case errors.Is(err, service.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "세션을 찾을 수 없습니다",
+		})
+
+	case errors.Is(err, auth.ErrInvalidScope):
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "비밀번호에 특수문자가 포함되어야 합니다",
+			"error": "지원하지 않는 scope입니다",
+			"code":  "INVALID_SCOPE",
+		})
+
+	case errors.Is(err, service.ErrScopeNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "요청한 scope를 사용할 권한이 없습니다",
+		})
+
+	case errors.Is(err, service.ErrPersonalTokenNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "토큰을 찾을 수 없습니다",
 		})
 
 	default:
@@ -95,7 +184,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Login(c.Request.Context(), &req)
+	meta := auth.SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+
+	resp, err := h.authService.Login(c.Request.Context(), &req, meta)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredentials) {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -103,28 +197,69 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "서버 오류가 발생했습니다",
-		})
+		h.handleError(c, err)
 		return
 	}
 
+	writeLoginResponse(c, h.cookies, middleware.WantsCookieSession(c), resp)
+}
+
+// writeLoginResponse는 로그인 응답을 씁니다.
+// 쿠키 세션이면 토큰을 쿠키로 보내고 본문에는 CSRF 토큰만 담습니다. (2단계 인증 대기 응답은 그대로)
+func writeLoginResponse(c *gin.Context, cookies middleware.SessionCookies, cookieSession bool, resp *dto.LoginResponse) {
+	if cookieSession && resp.AccessToken != "" {
+		csrfToken, err := cookies.Set(c, resp.AccessToken, resp.RefreshToken)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "서버 오류가 발생했습니다",
+			})
+			return
+		}
+		resp.AccessToken, resp.RefreshToken = "", ""
+		resp.CSRFToken = csrfToken
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
 // POST /api/auth/refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req dto.RefreshRequest
+	fromCookie := false
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "refresh_token이 필요합니다",
-		})
-		return
+		// 쿠키 세션은 본문 없이 Refresh Token 쿠키로 갱신
+		cookie, cookieErr := c.Cookie(middleware.RefreshTokenCookie)
+		if cookieErr != nil || cookie == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "refresh_token이 필요합니다",
+			})
+			return
+		}
+		if !middleware.ValidCSRF(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "CSRF 토큰이 없거나 올바르지 않습니다",
+				"code":  "INVALID_CSRF_TOKEN",
+			})
+			return
+		}
+		req.RefreshToken, fromCookie = cookie, true
 	}
 
 	resp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
+		if fromCookie {
+			h.cookies.Clear(c) // 더 이상 쓸 수 없는 세션
+		}
+
+		if errors.Is(err, auth.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "이미 사용된 토큰입니다. 보안을 위해 세션이 종료되었으니 다시 로그인해주세요",
+				"code":  "REFRESH_TOKEN_REUSED",
+			})
+			return
+		}
+
 		if errors.Is(err, auth.ErrExpiredToken) {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "세션이 만료되었습니다. 다시 로그인해주세요",
@@ -133,12 +268,28 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 			return
 		}
 
+		var suspendedErr *service.SuspendedError
+		if errors.As(err, &suspendedErr) {
+			writeSuspended(c, suspendedErr)
+			return
+		}
+
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "유효하지 않은 토큰입니다",
 		})
 		return
 	}
 
+	if fromCookie {
+		csrfToken, err := h.cookies.Set(c, resp.AccessToken, resp.RefreshToken)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		resp.AccessToken, resp.RefreshToken = "", ""
+		resp.CSRFToken = csrfToken
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -147,17 +298,333 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	claims := middleware.MustGetCurrentUser(c)
 
-	// 현재 Access Token 무효화
-	authHeader := c.GetHeader("Authorization")
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) == 2 {
-		_ = h.tokenService.RevokeAccessToken(c.Request.Context(), parts[1])
+	// 현재 Access Token 무효화 (Personal Access Token은 /me/tokens에서 폐기)
+	if token, _, err := middleware.ExtractToken(c); err == nil && !claims.IsPersonalToken() {
+		_ = h.tokenService.RevokeAccessToken(c.Request.Context(), token)
+	}
+
+	// 대리 로그인 토큰은 토큰만 무효화 (대리 로그인 종료, 관리자 세션은 유지)
+	if claims.IsImpersonated() {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "대리 로그인이 종료되었습니다",
+		})
+		return
 	}
 
-	// Refresh Token 삭제
-	_ = h.authService.Logout(c.Request.Context(), claims.UserID)
+	// 현재 세션 종료 (다른 기기는 유지)
+	_ = h.authService.Logout(c.Request.Context(), claims.UserID, claims.SessionID)
+	h.cookies.Clear(c)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "로그아웃되었습니다",
 	})
 }
+
+// VerifyEmail은 메일로 받은 토큰으로 이메일 인증을 완료합니다.
+// POST /api/auth/verify
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "token이 필요합니다",
+		})
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// 기존 Access Token에는 인증 전 상태가 담겨 있으므로 토큰 갱신 필요
+	c.JSON(http.StatusOK, gin.H{
+		"message": "이메일 인증이 완료되었습니다. 토큰을 갱신하면 바로 글을 작성할 수 있습니다",
+	})
+}
+
+// ResendVerification은 인증 메일을 다시 보냅니다.
+// POST /api/auth/resend-verification
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다",
+		})
+		return
+	}
+
+	if err := h.authService.ResendVerification(c.Request.Context(), req.Email); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// 가입 여부와 관계없이 같은 응답
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "인증이 필요한 계정이면 인증 메일을 다시 보냈습니다",
+	})
+}
+
+// ForgotPassword는 비밀번호 재설정 메일을 보냅니다.
+// POST /api/auth/password/forgot
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다",
+		})
+		return
+	}
+
+	if err := h.authService.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// 가입 여부와 관계없이 같은 응답
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "가입된 이메일이면 비밀번호 재설정 메일을 보냈습니다",
+	})
+}
+
+// ResetPassword는 재설정 토큰으로 비밀번호를 변경합니다.
+// POST /api/auth/password/reset
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "잘못된 요청 형식입니다",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "비밀번호가 변경되었습니다. 모든 기기에서 로그아웃되었으니 다시 로그인해주세요",
+	})
+}
+
+// VerifyTwoFactor는 로그인 대기 토큰과 인증 코드를 확인하고 토큰을 발급합니다.
+// POST /api/auth/2fa/verify
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorVerifyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "잘못된 요청 형식입니다",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	meta := auth.SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+
+	resp, err := h.authService.VerifyTwoFactor(c.Request.Context(), &req, meta)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	writeLoginResponse(c, h.cookies, middleware.WantsCookieSession(c), resp)
+}
+
+// SetupTwoFactor는 2단계 인증 등록을 시작합니다.
+// POST /api/v1/me/2fa/setup
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	resp, err := h.authService.SetupTwoFactor(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ConfirmTwoFactor는 인증 앱의 코드를 확인하고 2단계 인증을 활성화합니다.
+// POST /api/v1/me/2fa/confirm
+func (h *AuthHandler) ConfirmTwoFactor(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.TwoFactorConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "6자리 인증 코드가 필요합니다",
+		})
+		return
+	}
+
+	resp, err := h.authService.ConfirmTwoFactor(c.Request.Context(), claims.UserID, req.Code)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DisableTwoFactor는 2단계 인증을 해제합니다.
+// POST /api/v1/me/2fa/disable
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "비밀번호와 6자리 인증 코드가 필요합니다",
+		})
+		return
+	}
+
+	if err := h.authService.DisableTwoFactor(c.Request.Context(), claims.UserID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "2단계 인증이 해제되었습니다",
+	})
+}
+
+// UnlockAccount는 로그인 실패로 잠긴 계정을 해제합니다.
+// POST /api/v1/admin/users/:id/unlock
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않은 사용자 ID입니다",
+		})
+		return
+	}
+
+	if err := h.authService.UnlockAccount(c.Request.Context(), uint(userID)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "계정 잠금이 해제되었습니다",
+	})
+}
+
+// ListSessions는 로그인된 기기(세션) 목록을 조회합니다.
+// GET /api/v1/me/sessions
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession은 특정 기기(세션)를 로그아웃시킵니다.
+// DELETE /api/v1/me/sessions/:id
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	if err := h.authService.RevokeSession(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions는 현재 기기를 제외한 모든 기기를 로그아웃시킵니다.
+// DELETE /api/v1/me/sessions
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	revoked, err := h.authService.RevokeOtherSessions(c.Request.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{Revoked: revoked})
+}
+
+// CreatePersonalToken은 Personal Access Token을 발급합니다. 토큰 원문은 응답에서 한 번만 보여줍니다.
+// POST /api/v1/me/tokens
+func (h *AuthHandler) CreatePersonalToken(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.CreatePersonalTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "토큰 이름과 scope가 필요합니다",
+		})
+		return
+	}
+
+	resp, err := h.authService.CreatePersonalToken(c.Request.Context(), claims.UserID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListPersonalTokens는 Personal Access Token 목록을 조회합니다.
+// GET /api/v1/me/tokens
+func (h *AuthHandler) ListPersonalTokens(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	tokens, err := h.authService.ListPersonalTokens(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+	})
+}
+
+// RevokePersonalToken은 Personal Access Token을 폐기합니다.
+// DELETE /api/v1/me/tokens/:id
+func (h *AuthHandler) RevokePersonalToken(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않은 토큰 ID입니다",
+		})
+		return
+	}
+
+	if err := h.authService.RevokePersonalToken(c.Request.Context(), claims.UserID, uint(tokenID)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// JWKS는 Access Token 검증용 공개키 목록을 반환합니다.
+// 교체된 이전 키도 유예 기간 동안 포함됩니다.
+// GET /.well-known/jwks.json
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokenService.JWKS())
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"gorm-test/internal/dto"
 	"gorm-test/internal/service"
+	"gorm-test/pkg/apperror"
+	"gorm-test/pkg/problem"
 	"net/http"
 	"strconv"
 
@@ -33,12 +35,18 @@ func (h *CommentHandler) Create(c *gin.Context) {
 		return
 	}
 
-	comment, err := h.commentService.Create(uint(postID), &req)
+	comment, err := h.commentService.Create(c.Request.Context(), uint(postID), &req)
 	if err != nil {
 		if errors.Is(err, service.ErrPostNotExists) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", err.Error()))
 			return
 		}
+		if appErr, ok := apperror.AsAppError(err); ok {
+			// 필터 거부(422), 댓글 잠금(423) 등은 RFC 7807 형식으로 응답
+			c.Header("Content-Type", problem.ContentType)
+			c.JSON(appErr.HTTPStatus, problem.FromAppError(appErr, c.Request.URL.Path))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 생성에 실패했습니다"))
 		return
 	}
@@ -83,12 +91,16 @@ func (h *CommentHandler) Update(c *gin.Context) {
 		return
 	}
 
-	comment, err := h.commentService.Update(uint(id), &req)
+	comment, err := h.commentService.Update(c.Request.Context(), uint(id), &req)
 	if err != nil {
 		if errors.Is(err, service.ErrCommentNotFound) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", err.Error()))
 			return
 		}
+		if errors.Is(err, service.ErrForbidden) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "본인의 댓글만 수정할 수 있습니다"))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 수정에 실패했습니다"))
 		return
 	}
@@ -105,15 +117,179 @@ func (h *CommentHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.commentService.Delete(uint(id))
+	err = h.commentService.Delete(c.Request.Context(), uint(id))
 	if err != nil {
 		if errors.Is(err, service.ErrCommentNotFound) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", err.Error()))
 			return
 		}
+		if errors.Is(err, service.ErrForbidden) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "본인의 댓글만 삭제할 수 있습니다"))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 삭제에 실패했습니다"))
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore 삭제된 댓글 복구 (관리자)
+// POST /api/v1/admin/comments/:commentId/restore
+func (h *CommentHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	comment, err := h.commentService.Restore(uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrCommentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 복구에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(comment))
+}
+
+// ListPending 검토 대기 댓글 목록 (관리자, 오래된 순)
+// GET /api/v1/admin/comments/pending?page=1&size=20
+func (h *CommentHandler) ListPending(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	pagination := dto.NewPagination(page, size, 20, 100)
+
+	comments, meta, err := h.commentService.ListPending(pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "목록 조회에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(comments, meta))
+}
+
+// Approve 검토 대기 댓글 승인 (관리자)
+// POST /api/v1/admin/comments/:commentId/approve
+func (h *CommentHandler) Approve(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	comment, err := h.commentService.Approve(uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrCommentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "검토 대기 중인 댓글이 없습니다"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 승인에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(comment))
+}
+
+// Reject 검토 대기 댓글 거부 (관리자, 삭제)
+// POST /api/v1/admin/comments/:commentId/reject
+func (h *CommentHandler) Reject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	if err := h.commentService.Reject(uint(id)); err != nil {
+		if errors.Is(err, service.ErrCommentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "검토 대기 중인 댓글이 없습니다"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 거부에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Move 댓글 이동 (관리자, 하위 댓글 포함)
+// POST /api/v1/admin/comments/:commentId/move
+func (h *CommentHandler) Move(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	var req dto.MoveCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	comment, err := h.commentService.Move(uint(id), &req)
+	if err != nil {
+		h.handleTreeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(comment))
+}
+
+// Merge 댓글 병합 (관리자) - 대댓글을 target 아래로 옮기고 원본 댓글 삭제
+// POST /api/v1/admin/comments/:commentId/merge
+func (h *CommentHandler) Merge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	var req dto.MergeCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	if err := h.commentService.Merge(uint(id), req.TargetID); err != nil {
+		h.handleTreeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// DeleteTree 댓글 트리 삭제 (관리자, 하위 댓글 포함)
+// DELETE /api/v1/admin/comments/:commentId/tree
+func (h *CommentHandler) DeleteTree(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 댓글 ID입니다"))
+		return
+	}
+
+	if err := h.commentService.DeleteTree(uint(id)); err != nil {
+		h.handleTreeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *CommentHandler) handleTreeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, service.ErrPostNotExists):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", err.Error()))
+	case errors.Is(err, service.ErrInvalidCommentMove), errors.Is(err, service.ErrCommentTooDeep):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_MOVE", err.Error()))
+	default:
+		if appErr, ok := apperror.AsAppError(err); ok {
+			c.JSON(appErr.HTTPStatus, dto.ErrorResponse(appErr.Code, appErr.Message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "댓글 처리에 실패했습니다"))
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/service"
+	"gorm-test/middleware"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthHandler 내부 서비스용 토큰 확인/폐기 (RFC 7662, RFC 7009)
+// 에러는 OAuth 형식({"error": "...", "error_description": "..."})으로 응답합니다.
+type OAuthHandler struct {
+	introspectionService *service.TokenIntrospectionService
+}
+
+func NewOAuthHandler(introspectionService *service.TokenIntrospectionService) *OAuthHandler {
+	return &OAuthHandler{introspectionService: introspectionService}
+}
+
+// Introspect 토큰 확인
+// POST /oauth/introspect (client_secret_basic 또는 client_secret_post)
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	var req dto.TokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		writeOAuthError(c, http.StatusBadRequest, "invalid_request", "token 파라미터가 필요합니다")
+		return
+	}
+
+	resp, err := h.introspectionService.Introspect(c.Request.Context(), req.Token, req.TokenTypeHint)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "token introspection failed", "client_id", middleware.GetClientID(c), "error", err)
+		writeOAuthError(c, http.StatusInternalServerError, "server_error", "토큰을 확인하지 못했습니다")
+		return
+	}
+
+	// 토큰 상태는 바뀔 수 있으므로 캐시하지 않음
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Revoke 토큰 폐기 - 이미 사용할 수 없는 토큰도 200
+// POST /oauth/revoke (client_secret_basic 또는 client_secret_post)
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	var req dto.TokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		writeOAuthError(c, http.StatusBadRequest, "invalid_request", "token 파라미터가 필요합니다")
+		return
+	}
+
+	clientID := middleware.GetClientID(c)
+	err := h.introspectionService.Revoke(c.Request.Context(), clientID, req.Token, req.TokenTypeHint)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnsupportedTokenType) {
+			writeOAuthError(c, http.StatusBadRequest, "unsupported_token_type", "Personal Access Token은 사용자가 직접 폐기해야 합니다")
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "token revocation failed", "client_id", clientID, "error", err)
+		// 클라이언트가 다시 시도할 수 있도록 503 (RFC 7009 2.2.1)
+		writeOAuthError(c, http.StatusServiceUnavailable, "server_error", "토큰을 폐기하지 못했습니다")
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// writeOAuthError는 OAuth 에러 응답을 보냅니다. (RFC 6749 5.2)
+func writeOAuthError(c *gin.Context, status int, code, description string) {
+	c.Header("Cache-Control", "no-store")
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":             code,
+		"error_description": description,
+	})
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/oidc"
+	"gorm-test/internal/service"
+	"gorm-test/middleware"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcFlowCookie는 로그인 진행 상태를 담는 쿠키 이름입니다.
+const oidcFlowCookie = "oidc_flow"
+
+type OIDCHandler struct {
+	authService service.AuthService
+	providers   map[string]*oidc.Provider
+	stateCodec  *oidc.StateCodec
+	cookies     middleware.SessionCookies // 쿠키 세션 설정 (진행 상태 쿠키의 Secure 속성도 따름)
+}
+
+func NewOIDCHandler(authService service.AuthService, providers map[string]*oidc.Provider, stateCodec *oidc.StateCodec, cookies middleware.SessionCookies) *OIDCHandler {
+	return &OIDCHandler{
+		authService: authService,
+		providers:   providers,
+		stateCodec:  stateCodec,
+		cookies:     cookies,
+	}
+}
+
+// Login은 IdP 로그인 페이지로 이동시킵니다.
+// GET /api/auth/oidc/:provider/login?device_name=...&session=cookie
+func (h *OIDCHandler) Login(c *gin.Context) {
+	provider, ok := h.providers[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 방식입니다",
+		})
+		return
+	}
+
+	flow, err := oidc.NewFlowState(provider.Name(), c.Query("device_name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "서버 오류가 발생했습니다",
+		})
+		return
+	}
+	flow.CookieMode = c.Query("session") == "cookie"
+
+	authURL, err := provider.AuthCodeURL(c.Request.Context(), flow.State, flow.Nonce, flow.CodeVerifier)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "oidc discovery failed", "provider", provider.Name(), "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "외부 로그인 서비스에 연결할 수 없습니다",
+		})
+		return
+	}
+
+	cookie, err := h.stateCodec.Encode(flow)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "서버 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// IdP에서 돌아오는 top-level GET 요청에도 전송되도록 SameSite=Lax
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, cookie, int(h.stateCodec.Expiry().Seconds()), "/", "", h.cookies.Secure, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback은 IdP에서 돌아온 authorization code로 로그인합니다.
+// GET /api/auth/oidc/:provider/callback?code=...&state=...
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	provider, ok := h.providers[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 방식입니다",
+		})
+		return
+	}
+
+	// 진행 상태 쿠키는 한 번만 사용
+	raw, _ := c.Cookie(oidcFlowCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, "", -1, "/", "", h.cookies.Secure, true)
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "외부 로그인이 취소되었거나 실패했습니다",
+			"code":  "OIDC_" + idpErr,
+		})
+		return
+	}
+
+	flow, err := h.stateCodec.Decode(raw)
+	if err != nil || flow.Provider != provider.Name() || flow.State == "" || flow.State != c.Query("state") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "로그인 요청이 만료되었거나 올바르지 않습니다. 다시 시도해주세요",
+			"code":  "INVALID_OIDC_STATE",
+		})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "code가 필요합니다",
+		})
+		return
+	}
+
+	identity, err := provider.Exchange(c.Request.Context(), code, flow.CodeVerifier, flow.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidIDToken) || errors.Is(err, oidc.ErrNonceMismatch) {
+			slog.WarnContext(c.Request.Context(), "oidc id token rejected", "provider", provider.Name(), "error", err)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "외부 로그인 정보를 확인할 수 없습니다",
+				"code":  "INVALID_ID_TOKEN",
+			})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "oidc exchange failed", "provider", provider.Name(), "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "외부 로그인 서비스에 연결할 수 없습니다",
+		})
+		return
+	}
+
+	meta := auth.SessionMeta{
+		DeviceName: flow.DeviceName,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+	}
+
+	resp, err := h.authService.LoginWithIdentity(c.Request.Context(), identity, meta)
+	if err != nil {
+		if errors.Is(err, service.ErrExternalEmailNotVerified) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "외부 계정의 이메일이 인증되지 않아 로그인할 수 없습니다",
+				"code":  "EXTERNAL_EMAIL_NOT_VERIFIED",
+			})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "연결된 계정을 찾을 수 없습니다",
+			})
+			return
+		}
+		var suspendedErr *service.SuspendedError
+		if errors.As(err, &suspendedErr) {
+			writeSuspended(c, suspendedErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "서버 오류가 발생했습니다",
+		})
+		return
+	}
+
+	writeLoginResponse(c, h.cookies, flow.CookieMode, resp)
+}
@@ -54,7 +54,7 @@ func (h *PostHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	post, err := h.postService.GetByID(uint(id))
+	post, err := h.postService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		response.Error(c, err)
 		sentry.CaptureError(err)
@@ -153,13 +153,100 @@ func (h *PostHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// LockComments 댓글 잠금/해제
+// PUT /api/v1/posts/:postId/comment-lock
+func (h *PostHandler) LockComments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 ID입니다"))
+		return
+	}
+
+	var req dto.LockCommentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	post, err := h.postService.SetCommentLock(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if appErr, ok := apperror.AsAppError(err); ok {
+			c.JSON(appErr.HTTPStatus, dto.ErrorResponse(appErr.Code, appErr.Message))
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(post))
+}
+
+// ListPending 검토 대기 게시글 목록 (관리자, 오래된 순)
+// GET /api/v1/admin/posts/pending?page=1&size=20
+func (h *PostHandler) ListPending(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	pagination := dto.NewPagination(page, size, 20, 100)
+
+	posts, meta, err := h.postService.ListPending(pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "목록 조회에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(posts, meta))
+}
+
+// Approve 검토 대기 게시글 승인 (관리자)
+// POST /api/v1/admin/posts/:postId/approve
+func (h *PostHandler) Approve(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 ID입니다"))
+		return
+	}
+
+	post, err := h.postService.Approve(uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrPostNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "검토 대기 중인 게시글이 없습니다"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "게시글 승인에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(post))
+}
+
+// Reject 검토 대기 게시글 거부 (관리자, 삭제)
+// POST /api/v1/admin/posts/:postId/reject
+func (h *PostHandler) Reject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("postId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_ID", "유효하지 않은 ID입니다"))
+		return
+	}
+
+	if err := h.postService.Reject(uint(id)); err != nil {
+		if errors.Is(err, service.ErrPostNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "검토 대기 중인 게시글이 없습니다"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("SERVER_ERROR", "게시글 거부에 실패했습니다"))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
 func (h *PostHandler) handleError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrUnauthorized):
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "인증이 필요합니다"})
 	case errors.Is(err, service.ErrForbidden):
 		c.JSON(http.StatusForbidden, gin.H{"error": "권한이 없습니다"})
-	case errors.Is(err, repository.ErrPostNotFound):
+	case errors.Is(err, repository.ErrPostNotFound), errors.Is(err, service.ErrPostNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"error": "게시글을 찾을 수 없습니다"})
 	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
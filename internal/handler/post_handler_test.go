@@ -1,17 +1,23 @@
-package handler
+package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"gorm-test/internal/auth"
 	"gorm-test/internal/config"
 	"gorm-test/internal/domain"
+	"gorm-test/internal/handler"
 	"gorm-test/internal/repository"
 	"gorm-test/internal/router"
 	"gorm-test/internal/service"
+	"gorm-test/middleware"
+	"gorm-test/pkg/validator"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,24 +27,33 @@ import (
 )
 
 // PostHandlerSuite 테스트 스위트
+// 테스트 DB(PostgreSQL)에 연결할 수 없으면 건너뜁니다.
 type PostHandlerSuite struct {
 	suite.Suite
 	db     *gorm.DB
 	router *gin.Engine
+	tokens *auth.TokenService
+	store  *auth.MemoryTokenStore
+
+	author      *domain.User
+	accessToken string // author의 Access Token
 }
 
 // SetupSuite 테스트 시작 전 1회 실행
 func (s *PostHandlerSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
+	validator.RegisterGinValidations()
 
 	// 테스트 DB 연결
 	dsn := "host=localhost user=gouser password=gopassword dbname=godb_test port=5432 sslmode=disable"
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	s.Require().NoError(err)
+	if err != nil {
+		s.T().Skipf("테스트 DB에 연결할 수 없습니다: %v", err)
+	}
 	s.db = db
 
 	// 마이그레이션
-	db.AutoMigrate(&domain.Post{}, &domain.Comment{})
+	s.Require().NoError(db.AutoMigrate(&domain.User{}, &domain.Post{}, &domain.Comment{}))
 
 	// 의존성 주입
 	cfg := &config.Config{
@@ -48,21 +63,34 @@ func (s *PostHandlerSuite) SetupSuite() {
 		},
 	}
 
+	keys, err := auth.NewKeyManager(context.Background(), auth.NewMemoryKeyStore(), auth.KeyManagerConfig{Algorithm: auth.AlgEdDSA})
+	s.Require().NoError(err)
+	s.store = auth.NewMemoryTokenStore(time.Hour)
+	s.tokens = auth.NewTokenService(keys, 15*time.Minute, 24*time.Hour, s.store)
+
+	limits, err := middleware.NewRateLimits(middleware.NewMemoryRateLimitStore(), nil)
+	s.Require().NoError(err)
+
 	postRepo := repository.NewPostRepository(db)
 	commentRepo := repository.NewCommentRepository(db)
-	postService := service.NewPostService(postRepo, cfg)
-	commentService := service.NewCommentService(commentRepo, postRepo)
-	postHandler := NewPostHandler(postService)
-	commentHandler := NewCommentHandler(commentService)
-
-	r := router.NewRouter(postHandler, commentHandler)
-	s.router = r.Setup()
+	postService := service.NewPostService(postRepo, cfg, nil, nil)
+	commentService := service.NewCommentService(commentRepo, postRepo, nil, nil)
+	postHandler := handler.NewPostHandler(postService)
+	commentHandler := handler.NewCommentHandler(commentService)
+
+	// 게시글/댓글 외의 핸들러는 사용하지 않음
+	r := router.NewRouter(postHandler, commentHandler, nil, nil, nil, nil, nil, nil, nil)
+	s.router = r.Setup(s.tokens, nil, auth.NewClientCredentials(nil), limits, false)
 }
 
 // TearDownSuite 테스트 종료 후 1회 실행
 func (s *PostHandlerSuite) TearDownSuite() {
+	if s.db == nil {
+		return
+	}
+	s.store.Close()
 	// 테스트 테이블 삭제
-	s.db.Migrator().DropTable(&domain.Comment{}, &domain.Post{})
+	s.db.Migrator().DropTable(&domain.Comment{}, &domain.Post{}, &domain.User{})
 }
 
 // SetupTest 각 테스트 전 실행
@@ -70,22 +98,44 @@ func (s *PostHandlerSuite) SetupTest() {
 	// 테이블 초기화
 	s.db.Exec("TRUNCATE TABLE comments RESTART IDENTITY CASCADE")
 	s.db.Exec("TRUNCATE TABLE posts RESTART IDENTITY CASCADE")
+	s.db.Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
+
+	// 이메일 인증을 마친 작성자
+	verifiedAt := time.Now()
+	s.author = &domain.User{Email: "tester@example.com", Password: "-", Username: "테스터", EmailVerifiedAt: &verifiedAt}
+	s.Require().NoError(s.db.Create(s.author).Error)
+
+	_, session, err := s.tokens.CreateSession(context.Background(), s.author.ID, auth.SessionMeta{})
+	s.Require().NoError(err)
+	s.accessToken, err = s.tokens.GenerateAccessToken(auth.TokenSubject{
+		UserID:        s.author.ID,
+		Email:         s.author.Email,
+		Username:      s.author.Username,
+		Role:          string(domain.RoleUser),
+		EmailVerified: true,
+	}, session)
+	s.Require().NoError(err)
 }
 
 func TestPostHandlerSuite(t *testing.T) {
 	suite.Run(t, new(PostHandlerSuite))
 }
 
+// authorized는 작성자의 Access Token을 붙입니다.
+func (s *PostHandlerSuite) authorized(req *http.Request) *http.Request {
+	req.Header.Set(middleware.AuthorizationHeader, middleware.AuthorizationType+" "+s.accessToken)
+	return req
+}
+
 func (s *PostHandlerSuite) TestCreatePost() {
 	// Given
 	body := map[string]string{
 		"title":   "테스트 게시글",
 		"content": "테스트 내용입니다",
-		"author":  "테스터",
 	}
 	jsonBody, _ := json.Marshal(body)
 
-	req, _ := http.NewRequest("POST", "/api/v1/posts", bytes.NewBuffer(jsonBody))
+	req := s.authorized(httptest.NewRequest("POST", "/api/v1/posts", bytes.NewBuffer(jsonBody)))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -104,13 +154,24 @@ func (s *PostHandlerSuite) TestCreatePost() {
 	assert.Equal(s.T(), "테스터", data["author"])
 }
 
+func (s *PostHandlerSuite) TestCreatePost_Unauthorized() {
+	jsonBody, _ := json.Marshal(map[string]string{"title": "테스트 게시글", "content": "내용"})
+	req, _ := http.NewRequest("POST", "/api/v1/posts", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	s.router.ServeHTTP(rec, req)
+
+	assert.Equal(s.T(), http.StatusUnauthorized, rec.Code)
+}
+
 func (s *PostHandlerSuite) TestGetPostList() {
 	// Given: 게시글 3개 생성
 	for i := 1; i <= 3; i++ {
 		s.db.Create(&domain.Post{
-			Title:   fmt.Sprintf("게시글 %d", i),
-			Content: fmt.Sprintf("내용 %d", i),
-			Author:  "테스터",
+			Title:    fmt.Sprintf("게시글 %d", i),
+			Content:  fmt.Sprintf("내용 %d", i),
+			AuthorID: s.author.ID,
 		})
 	}
 
@@ -136,9 +197,9 @@ func (s *PostHandlerSuite) TestGetPostList() {
 func (s *PostHandlerSuite) TestGetPostByID() {
 	// Given
 	post := &domain.Post{
-		Title:   "조회 테스트",
-		Content: "내용",
-		Author:  "테스터",
+		Title:    "조회 테스트",
+		Content:  "내용",
+		AuthorID: s.author.ID,
 	}
 	s.db.Create(post)
 
@@ -156,6 +217,7 @@ func (s *PostHandlerSuite) TestGetPostByID() {
 
 	data := response["data"].(map[string]interface{})
 	assert.Equal(s.T(), "조회 테스트", data["title"])
+	assert.Equal(s.T(), "테스터", data["author"])
 	assert.Equal(s.T(), float64(1), data["views"]) // 조회수 증가
 }
 
@@ -170,7 +232,7 @@ func (s *PostHandlerSuite) TestGetPostByID_NotFound() {
 
 func (s *PostHandlerSuite) TestCreateComment() {
 	// Given: 게시글 생성
-	post := &domain.Post{Title: "테스트", Content: "내용", Author: "작성자"}
+	post := &domain.Post{Title: "테스트", Content: "내용", AuthorID: s.author.ID}
 	s.db.Create(post)
 
 	body := map[string]string{
@@ -179,9 +241,9 @@ func (s *PostHandlerSuite) TestCreateComment() {
 	}
 	jsonBody, _ := json.Marshal(body)
 
-	req, _ := http.NewRequest("POST",
+	req := s.authorized(httptest.NewRequest("POST",
 		fmt.Sprintf("/api/v1/posts/%d/comments", post.ID),
-		bytes.NewBuffer(jsonBody))
+		bytes.NewBuffer(jsonBody)))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -201,7 +263,7 @@ func (s *PostHandlerSuite) TestCreateComment() {
 
 func (s *PostHandlerSuite) TestCreateReply() {
 	// Given: 게시글과 댓글 생성
-	post := &domain.Post{Title: "테스트", Content: "내용", Author: "작성자"}
+	post := &domain.Post{Title: "테스트", Content: "내용", AuthorID: s.author.ID}
 	s.db.Create(post)
 
 	comment := &domain.Comment{PostID: post.ID, Content: "첫 댓글", Author: "댓글러"}
@@ -214,9 +276,9 @@ func (s *PostHandlerSuite) TestCreateReply() {
 	}
 	jsonBody, _ := json.Marshal(body)
 
-	req, _ := http.NewRequest("POST",
+	req := s.authorized(httptest.NewRequest("POST",
 		fmt.Sprintf("/api/v1/posts/%d/comments", post.ID),
-		bytes.NewBuffer(jsonBody))
+		bytes.NewBuffer(jsonBody)))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
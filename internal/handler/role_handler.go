@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"gorm-test/internal/dto"
+	"gorm-test/internal/service"
+	"gorm-test/pkg/apperror"
+	"gorm-test/pkg/problem"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler 역할/권한 관리 (관리자)
+type RoleHandler struct {
+	roleService *service.RoleService
+}
+
+func NewRoleHandler(roleService *service.RoleService) *RoleHandler {
+	return &RoleHandler{roleService: roleService}
+}
+
+// ListPermissions 정의된 권한 목록
+// GET /api/v1/admin/permissions
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse(h.roleService.ListPermissions()))
+}
+
+// List 역할 목록
+// GET /api/v1/admin/roles
+func (h *RoleHandler) List(c *gin.Context) {
+	roles, err := h.roleService.List(c.Request.Context())
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(roles))
+}
+
+// Create 역할 생성
+// POST /api/v1/admin/roles
+func (h *RoleHandler) Create(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	role, err := h.roleService.Create(c.Request.Context(), &req)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(role))
+}
+
+// Update 역할 설명/권한 수정
+// PUT /api/v1/admin/roles/:name
+func (h *RoleHandler) Update(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	role, err := h.roleService.Update(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(role))
+}
+
+// Delete 역할 삭제
+// DELETE /api/v1/admin/roles/:name
+func (h *RoleHandler) Delete(c *gin.Context) {
+	if err := h.roleService.Delete(c.Request.Context(), c.Param("name")); err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AssignRole 사용자 역할 변경 (대상 사용자는 다시 로그인해야 함)
+// PUT /api/v1/admin/users/:id/role
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	if err := h.roleService.AssignRole(c.Request.Context(), userID, req.Role); err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeProblem은 AppError를 RFC 7807 형식으로 응답합니다. AppError가 아니면 500입니다.
+func writeProblem(c *gin.Context, err error) {
+	appErr, ok := apperror.AsAppError(err)
+	if !ok {
+		appErr = apperror.InternalError(err)
+	}
+
+	c.Header("Content-Type", problem.ContentType)
+	c.JSON(appErr.HTTPStatus, problem.FromAppError(appErr, c.Request.URL.Path))
+}
@@ -1 +1,126 @@
 package handler
+
+import (
+	"gorm-test/internal/dto"
+	"gorm-test/internal/service"
+	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler 내 계정 관리 (프로필, 비밀번호 변경, 탈퇴, 로그인 기록)
+type UserHandler struct {
+	userService service.UserService
+}
+
+func NewUserHandler(userService service.UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// GetProfile 내 프로필
+// GET /api/v1/me
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	profile, err := h.userService.GetProfile(c.Request.Context(), claims.UserID)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(profile))
+}
+
+// UpdateProfile 프로필 수정 (보낸 필드만)
+// PATCH /api/v1/me
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	profile, err := h.userService.UpdateProfile(c.Request.Context(), claims.UserID, &req)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(profile))
+}
+
+// ChangePassword 비밀번호 변경 (모든 기기에서 로그아웃되므로 다시 로그인해야 함)
+// POST /api/v1/me/password
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	if err := h.userService.ChangePassword(c.Request.Context(), claims.UserID, req.CurrentPassword, req.NewPassword); err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteAccount 탈퇴 요청 (유예 기간 후 게시글/댓글 익명화, 계정 삭제)
+// DELETE /api/v1/me
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblem(c, apperror.FromValidationErrors(err))
+		return
+	}
+
+	resp, err := h.userService.DeleteAccount(c.Request.Context(), claims.UserID, claims.SessionID, req.Password)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse(resp))
+}
+
+// CancelDeletion 탈퇴 취소
+// DELETE /api/v1/me/deletion
+func (h *UserHandler) CancelDeletion(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	profile, err := h.userService.CancelDeletion(c.Request.Context(), claims.UserID)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(profile))
+}
+
+// ListLogins 내 로그인 기록 (최신순, 실패 포함)
+// GET /api/v1/me/logins?page=&size=
+func (h *UserHandler) ListLogins(c *gin.Context) {
+	claims := middleware.MustGetCurrentUser(c)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "20"))
+	pagination := dto.NewPagination(page, size, 20, 100)
+
+	logins, meta, err := h.userService.ListLogins(c.Request.Context(), claims.UserID, pagination)
+	if err != nil {
+		writeProblem(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessWithMeta(logins, meta))
+}
@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"fmt"
+	"gorm-test/internal/config"
+)
+
+// NewFromConfig는 설정의 transport에 따라 Mailer를 생성합니다.
+func NewFromConfig(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Transport {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.From), nil
+	case "file", "":
+		dir := cfg.OutboxDir
+		if dir == "" {
+			dir = "tmp/outbox"
+		}
+		return NewFileMailer(dir, cfg.From)
+	default:
+		return nil, fmt.Errorf("mailer: 알 수 없는 transport: %s", cfg.Transport)
+	}
+}
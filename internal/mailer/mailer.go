@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoRecipient = errors.New("mailer: no recipient")
+
+// Message는 발송할 메일입니다. 본문은 일반 텍스트입니다.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer는 메일 발송 인터페이스입니다.
+//
+//	운영: SMTPMailer
+//	로컬 개발/테스트: FileMailer (outbox 디렉터리에 .eml 파일로 저장)
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,57 @@
+package oidc
+
+import (
+	"encoding/json"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IDTokenClaims는 ID Token에서 사용하는 클레임입니다.
+type IDTokenClaims struct {
+	Nonce             string   `json:"nonce"`
+	AuthorizedParty   string   `json:"azp"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	jwt.RegisteredClaims
+}
+
+// Identity는 검증된 외부 사용자 정보입니다.
+type Identity struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+func (c *IDTokenClaims) identity(provider string) *Identity {
+	return &Identity{
+		Provider:          provider,
+		Subject:           c.Subject,
+		Email:             c.Email,
+		EmailVerified:     bool(c.EmailVerified),
+		Name:              c.Name,
+		PreferredUsername: c.PreferredUsername,
+	}
+}
+
+// flexBool은 true/"true" 모두 받아들입니다. (일부 IdP는 email_verified를 문자열로 보냄)
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		*b = flexBool(v)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*b = flexBool(s == "true")
+	return nil
+}
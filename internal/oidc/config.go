@@ -0,0 +1,35 @@
+package oidc
+
+import (
+	"fmt"
+	"gorm-test/internal/config"
+	"strings"
+)
+
+// NewProvidersFromConfig는 설정의 IdP 목록으로 Provider를 생성합니다. (이름 -> Provider)
+func NewProvidersFromConfig(cfg config.OIDCConfig) (map[string]*Provider, error) {
+	// 상태 쿠키 서명 키가 없으면 누구나 state를 위조할 수 있음
+	if len(cfg.Providers) > 0 && (cfg.StateSecret == "" || strings.Contains(cfg.StateSecret, "${")) {
+		return nil, fmt.Errorf("oidc: state_secret이 설정되지 않았습니다 (OIDC_STATE_SECRET 환경 변수 확인)")
+	}
+
+	providers := make(map[string]*Provider, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		if p.Name == "" || p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc: provider 설정에 name, issuer, client_id, redirect_url이 필요합니다")
+		}
+		if _, exists := providers[p.Name]; exists {
+			return nil, fmt.Errorf("oidc: 중복된 provider 이름: %s", p.Name)
+		}
+
+		providers[p.Name] = NewProvider(Config{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}, nil)
+	}
+	return providers, nil
+}
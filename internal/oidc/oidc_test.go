@@ -0,0 +1,376 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm-test/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+// mockIdP는 Discovery, 토큰 엔드포인트, JWKS를 제공하는 테스트용 IdP입니다.
+type mockIdP struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	keys       map[string]*rsa.PrivateKey // 공개 중인 키
+	signKid    string                     // 서명에 사용할 키
+	idToken    func(code, verifier string) string
+	jwksHits   int
+	lastForm   url.Values
+	lastClient string
+}
+
+func newMockIdP() *mockIdP {
+	m := &mockIdP{keys: map[string]*rsa.PrivateKey{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.jwksHits++
+
+		keys := []map[string]string{}
+		for kid, key := range m.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		clientID, _, _ := r.BasicAuth()
+
+		m.mu.Lock()
+		m.lastForm = r.PostForm
+		m.lastClient = clientID
+		issue := m.idToken
+		m.mu.Unlock()
+
+		if r.PostForm.Get("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "at",
+			"token_type":   "Bearer",
+			"id_token":     issue(r.PostForm.Get("code"), r.PostForm.Get("code_verifier")),
+		})
+	})
+	m.server = httptest.NewServer(mux)
+	return m
+}
+
+// rotate는 새 서명 키를 추가하고 이후 토큰을 그 키로 서명합니다.
+func (m *mockIdP) rotate(kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[kid] = key
+	m.signKid = kid
+	return key
+}
+
+func (m *mockIdP) sign(claims jwt.MapClaims) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return signWith(m.keys[m.signKid], m.signKid, claims)
+}
+
+func signWith(key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// OIDCSuite 테스트 스위트
+type OIDCSuite struct {
+	suite.Suite
+	idp      *mockIdP
+	provider *Provider
+}
+
+const (
+	testClientID = "test-client"
+	testNonce    = "test-nonce"
+)
+
+func (s *OIDCSuite) SetupTest() {
+	s.idp = newMockIdP()
+	s.idp.rotate("key-1")
+	s.provider = NewProvider(Config{
+		Name:         "mock",
+		Issuer:       s.idp.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/api/auth/oidc/mock/callback",
+	}, s.idp.server.Client())
+}
+
+func (s *OIDCSuite) TearDownTest() {
+	s.idp.server.Close()
+}
+
+// validClaims는 검증을 통과하는 ID Token 클레임입니다.
+func (s *OIDCSuite) validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            s.idp.server.URL,
+		"sub":            "user-123",
+		"aud":            testClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          testNonce,
+		"email":          "alice@example.com",
+		"email_verified": "true",
+		"name":           "Alice",
+	}
+}
+
+func (s *OIDCSuite) issue(claims jwt.MapClaims) {
+	s.idp.idToken = func(code, verifier string) string {
+		return s.idp.sign(claims)
+	}
+}
+
+func (s *OIDCSuite) TestAuthCodeURL_PKCE() {
+	raw, err := s.provider.AuthCodeURL(context.Background(), "st", testNonce, "verifier-value")
+	s.Require().NoError(err)
+
+	u, err := url.Parse(raw)
+	s.Require().NoError(err)
+	q := u.Query()
+	s.Equal(s.idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	s.Equal("code", q.Get("response_type"))
+	s.Equal(testClientID, q.Get("client_id"))
+	s.Equal("st", q.Get("state"))
+	s.Equal(testNonce, q.Get("nonce"))
+	s.Equal("S256", q.Get("code_challenge_method"))
+	s.Equal(CodeChallengeS256("verifier-value"), q.Get("code_challenge"))
+	s.Equal("openid email profile", q.Get("scope"))
+}
+
+func (s *OIDCSuite) TestCodeChallengeS256() {
+	// RFC 7636 Appendix B
+	s.Equal("E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func (s *OIDCSuite) TestExchange_Success() {
+	s.issue(s.validClaims())
+
+	identity, err := s.provider.Exchange(context.Background(), "valid-code", "verifier-value", testNonce)
+	s.Require().NoError(err)
+	s.Equal("mock", identity.Provider)
+	s.Equal("user-123", identity.Subject)
+	s.Equal("alice@example.com", identity.Email)
+	s.True(identity.EmailVerified)
+	s.Equal("Alice", identity.Name)
+
+	// PKCE verifier와 client 인증 전달
+	s.Equal("verifier-value", s.idp.lastForm.Get("code_verifier"))
+	s.Equal("authorization_code", s.idp.lastForm.Get("grant_type"))
+	s.Equal(testClientID, s.idp.lastClient)
+}
+
+func (s *OIDCSuite) TestExchange_InvalidCode() {
+	s.issue(s.validClaims())
+
+	_, err := s.provider.Exchange(context.Background(), "wrong-code", "verifier-value", testNonce)
+	s.Error(err)
+	s.NotErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_NonceMismatch() {
+	s.issue(s.validClaims())
+
+	_, err := s.provider.Exchange(context.Background(), "valid-code", "verifier-value", "other-nonce")
+	s.ErrorIs(err, ErrNonceMismatch)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_WrongAudience() {
+	claims := s.validClaims()
+	claims["aud"] = "another-client"
+
+	_, err := s.provider.VerifyIDToken(context.Background(), s.idp.sign(claims), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_MultipleAudienceRequiresAZP() {
+	claims := s.validClaims()
+	claims["aud"] = []string{testClientID, "another-client"}
+
+	_, err := s.provider.VerifyIDToken(context.Background(), s.idp.sign(claims), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+
+	claims["azp"] = testClientID
+	_, err = s.provider.VerifyIDToken(context.Background(), s.idp.sign(claims), testNonce)
+	s.NoError(err)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_WrongIssuer() {
+	claims := s.validClaims()
+	claims["iss"] = "https://evil.example.com"
+
+	_, err := s.provider.VerifyIDToken(context.Background(), s.idp.sign(claims), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_Expired() {
+	claims := s.validClaims()
+	claims["iat"] = time.Now().Add(-time.Hour).Unix()
+	claims["exp"] = time.Now().Add(-10 * time.Minute).Unix()
+
+	_, err := s.provider.VerifyIDToken(context.Background(), s.idp.sign(claims), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_BadSignature() {
+	// JWKS에 없는 키로 kid만 사칭
+	forged, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	_, err = s.provider.VerifyIDToken(context.Background(), signWith(forged, "key-1", s.validClaims()), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_RejectsHS256() {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, s.validClaims())
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString([]byte("secret"))
+	s.Require().NoError(err)
+
+	_, err = s.provider.VerifyIDToken(context.Background(), signed, testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_KeyRotation() {
+	ctx := context.Background()
+
+	_, err := s.provider.VerifyIDToken(ctx, s.idp.sign(s.validClaims()), testNonce)
+	s.Require().NoError(err)
+	s.Equal(1, s.idp.jwksHits)
+
+	// 캐시된 키는 다시 가져오지 않음
+	_, err = s.provider.VerifyIDToken(ctx, s.idp.sign(s.validClaims()), testNonce)
+	s.Require().NoError(err)
+	s.Equal(1, s.idp.jwksHits)
+
+	// 새 kid가 오면 JWKS를 다시 가져옴 (최소 간격이 지난 뒤)
+	s.idp.rotate("key-2")
+	s.provider.keys.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+
+	_, err = s.provider.VerifyIDToken(ctx, s.idp.sign(s.validClaims()), testNonce)
+	s.Require().NoError(err)
+	s.Equal(2, s.idp.jwksHits)
+}
+
+func (s *OIDCSuite) TestVerifyIDToken_UnknownKidThrottled() {
+	ctx := context.Background()
+
+	_, err := s.provider.VerifyIDToken(ctx, s.idp.sign(s.validClaims()), testNonce)
+	s.Require().NoError(err)
+
+	// 최소 간격 안에서는 모르는 kid로 IdP를 다시 호출하지 않음
+	unknown, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	_, err = s.provider.VerifyIDToken(ctx, signWith(unknown, "unknown", s.validClaims()), testNonce)
+	s.ErrorIs(err, ErrInvalidIDToken)
+	s.Equal(1, s.idp.jwksHits)
+}
+
+func (s *OIDCSuite) TestDiscovery_IssuerMismatch() {
+	provider := NewProvider(Config{
+		Name:     "mock",
+		Issuer:   "http://127.0.0.1:1",
+		ClientID: testClientID,
+	}, s.idp.server.Client())
+	provider.cfg.Issuer = s.idp.server.URL + "/tenant"
+
+	_, err := provider.AuthCodeURL(context.Background(), "st", testNonce, "v")
+	s.Error(err)
+}
+
+func (s *OIDCSuite) TestStateCodec() {
+	codec := NewStateCodec("state-secret", time.Minute)
+
+	flow, err := NewFlowState("mock", "laptop")
+	s.Require().NoError(err)
+
+	encoded, err := codec.Encode(flow)
+	s.Require().NoError(err)
+
+	decoded, err := codec.Decode(encoded)
+	s.Require().NoError(err)
+	s.Equal(flow, decoded)
+
+	// 다른 secret으로 서명된 값 거부
+	_, err = NewStateCodec("other-secret", time.Minute).Decode(encoded)
+	s.ErrorIs(err, ErrInvalidState)
+
+	_, err = codec.Decode(encoded + "x")
+	s.ErrorIs(err, ErrInvalidState)
+
+	_, err = codec.Decode("")
+	s.ErrorIs(err, ErrInvalidState)
+}
+
+func TestOIDCSuite(t *testing.T) {
+	suite.Run(t, new(OIDCSuite))
+}
+
+func (s *OIDCSuite) TestNewProvidersFromConfig_RequiresStateSecret() {
+	provider := config.OIDCProviderConfig{
+		Name:        "company",
+		Issuer:      s.idp.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:8080/callback",
+	}
+
+	for _, secret := range []string{"", "${OIDC_STATE_SECRET}"} {
+		_, err := NewProvidersFromConfig(config.OIDCConfig{StateSecret: secret, Providers: []config.OIDCProviderConfig{provider}})
+		s.Error(err, secret)
+	}
+
+	providers, err := NewProvidersFromConfig(config.OIDCConfig{StateSecret: "secret", Providers: []config.OIDCProviderConfig{provider}})
+	s.Require().NoError(err)
+	s.Len(providers, 1)
+
+	// IdP가 없으면 state_secret 불필요
+	_, err = NewProvidersFromConfig(config.OIDCConfig{})
+	s.NoError(err)
+}
@@ -0,0 +1,27 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// RandomString은 URL에 안전한 랜덤 문자열을 생성합니다. (state, nonce, code_verifier)
+func RandomString(nBytes int) (string, error) {
+	b := make([]byte, nBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewCodeVerifier는 PKCE code_verifier를 생성합니다. (RFC 7636 - 43~128자)
+func NewCodeVerifier() (string, error) {
+	return RandomString(32) // 43자
+}
+
+// CodeChallengeS256은 code_verifier의 S256 code_challenge를 반환합니다.
+func CodeChallengeS256(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
@@ -0,0 +1,112 @@
+package oidc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidState = errors.New("oidc: invalid or expired login state")
+)
+
+// flowAudience는 로그인 진행 상태 토큰의 aud 클레임입니다.
+const flowAudience = "oidc-flow"
+
+// FlowState는 IdP로 보낸 뒤 callback에서 확인할 값입니다.
+//
+//	서버에 저장하지 않고 서명한 뒤 HttpOnly 쿠키로 브라우저에 맡깁니다.
+//	State는 CSRF 방지, Nonce는 ID Token 재사용 방지, CodeVerifier는 PKCE 용도입니다.
+type FlowState struct {
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	DeviceName   string `json:"device_name,omitempty"`
+	CookieMode   bool   `json:"cookie_mode,omitempty"` // 로그인 후 토큰을 쿠키 세션으로 전달
+}
+
+type flowClaims struct {
+	FlowState
+	jwt.RegisteredClaims
+}
+
+// NewFlowState는 새 로그인 진행 상태를 생성합니다.
+func NewFlowState(provider, deviceName string) (*FlowState, error) {
+	state, err := RandomString(24)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := RandomString(24)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := NewCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return &FlowState{
+		Provider:     provider,
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		DeviceName:   deviceName,
+	}, nil
+}
+
+// StateCodec은 FlowState를 서명된 토큰으로 변환합니다.
+type StateCodec struct {
+	secret []byte
+	expiry time.Duration
+}
+
+// NewStateCodec 생성자
+func NewStateCodec(secret string, expiry time.Duration) *StateCodec {
+	if expiry <= 0 {
+		expiry = 10 * time.Minute
+	}
+	return &StateCodec{secret: []byte(secret), expiry: expiry}
+}
+
+// Expiry는 상태 토큰 유효 시간을 반환합니다. (쿠키 Max-Age)
+func (c *StateCodec) Expiry() time.Duration {
+	return c.expiry
+}
+
+// Encode는 FlowState를 서명합니다.
+func (c *StateCodec) Encode(state *FlowState) (string, error) {
+	now := time.Now()
+	claims := flowClaims{
+		FlowState: *state,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{flowAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(c.expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(c.secret)
+}
+
+// Decode는 서명을 확인하고 FlowState를 반환합니다.
+func (c *StateCodec) Decode(token string) (*FlowState, error) {
+	parsed, err := jwt.ParseWithClaims(
+		token,
+		&flowClaims{},
+		func(token *jwt.Token) (interface{}, error) {
+			return c.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(flowAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+
+	claims, ok := parsed.Claims.(*flowClaims)
+	if !ok || !parsed.Valid {
+		return nil, ErrInvalidState
+	}
+	return &claims.FlowState, nil
+}
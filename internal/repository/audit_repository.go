@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditChainLockID는 해시 체인에 이벤트를 하나씩 추가하기 위한 advisory lock 키입니다.
+const auditChainLockID = 0x61756469 // "audi"
+
+const auditBatchSize = 500
+
+// errStopIteration은 배치 조회를 중단할 때 사용합니다.
+var errStopIteration = errors.New("stop iteration")
+
+// AuditRepository는 감사 로그 저장소입니다. 추가만 가능하고 수정/삭제 메서드는 없습니다.
+type AuditRepository interface {
+	// Append는 직전 이벤트 해시를 이어서 이벤트를 추가합니다. (PrevHash, Hash를 채움)
+	Append(ctx context.Context, event *domain.AuditEvent) error
+	// Search는 검색 조건에 맞는 이벤트를 최신순으로 조회합니다. (전체 개수 포함)
+	Search(ctx context.Context, params *dto.AuditSearchParams, pagination *dto.Pagination) ([]*domain.AuditEvent, int64, error)
+	// Each는 검색 조건에 맞는 이벤트를 오래된 순으로 fn에 넘깁니다. (내보내기)
+	Each(ctx context.Context, params *dto.AuditSearchParams, fn func(*domain.AuditEvent) error) error
+	// Verify는 해시 체인을 처음부터 검사합니다.
+	// 검사한 이벤트 수, 체인이 처음 끊긴 이벤트(이상이 없으면 nil), 마지막 해시를 반환합니다.
+	Verify(ctx context.Context) (checked int64, broken *domain.AuditEvent, lastHash string, err error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Append(ctx context.Context, event *domain.AuditEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 동시에 추가되면 체인이 갈라지므로 트랜잭션 단위로 직렬화 (커밋/롤백 시 해제)
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockID).Error; err != nil {
+			return err
+		}
+
+		var last domain.AuditEvent
+		err := tx.Select("hash").Order("id DESC").Take(&last).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// DB에는 마이크로초까지 저장되므로 맞춰야 검증 시 같은 해시가 나옴
+		event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		event.PrevHash = last.Hash
+		event.Hash = event.ComputeHash()
+		return tx.Create(event).Error
+	})
+}
+
+func (r *auditRepository) Search(ctx context.Context, params *dto.AuditSearchParams, pagination *dto.Pagination) ([]*domain.AuditEvent, int64, error) {
+	var events []*domain.AuditEvent
+	var total int64
+
+	query := r.filter(r.db.WithContext(ctx).Model(&domain.AuditEvent{}), params)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("id DESC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Size).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (r *auditRepository) Each(ctx context.Context, params *dto.AuditSearchParams, fn func(*domain.AuditEvent) error) error {
+	var batch []*domain.AuditEvent
+	return r.filter(r.db.WithContext(ctx), params).
+		FindInBatches(&batch, auditBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, event := range batch {
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+func (r *auditRepository) Verify(ctx context.Context) (int64, *domain.AuditEvent, string, error) {
+	var (
+		batch []*domain.AuditEvent
+		chain auditChain
+	)
+
+	err := r.db.WithContext(ctx).
+		FindInBatches(&batch, auditBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, event := range batch {
+				if !chain.add(event) {
+					return errStopIteration
+				}
+			}
+			return nil
+		}).Error
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return 0, nil, "", err
+	}
+
+	return chain.checked, chain.broken, chain.lastHash, nil
+}
+
+// auditChain은 이벤트를 오래된 순으로 받아 해시 체인을 검사합니다.
+type auditChain struct {
+	checked  int64
+	broken   *domain.AuditEvent
+	lastHash string
+}
+
+// add는 event가 직전 이벤트에 이어지면 true를 반환합니다. 끊겼으면 broken에 남기고 false를 반환합니다.
+func (c *auditChain) add(event *domain.AuditEvent) bool {
+	if event.PrevHash != c.lastHash || event.Hash != event.ComputeHash() {
+		c.broken = event
+		return false
+	}
+	c.lastHash = event.Hash
+	c.checked++
+	return true
+}
+
+func (r *auditRepository) filter(query *gorm.DB, params *dto.AuditSearchParams) *gorm.DB {
+	if params.Action != "" {
+		query = query.Where("action = ? OR action LIKE ?", params.Action, params.Action+".%")
+	}
+	if params.ActorID != nil {
+		query = query.Where("actor_id = ?", *params.ActorID)
+	}
+	if params.TargetID != nil {
+		query = query.Where("target_id = ?", *params.TargetID)
+	}
+	if params.IP != "" {
+		query = query.Where("ip = ?", params.IP)
+	}
+	if params.RequestID != "" {
+		query = query.Where("request_id = ?", params.RequestID)
+	}
+
+	// 날짜 범위 (To는 해당 날짜 포함)
+	if params.From != nil {
+		query = query.Where("created_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("created_at < ?", params.To.AddDate(0, 0, 1))
+	}
+	return query
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"gorm-test/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// auditEvents는 해시 체인으로 이어진 이벤트 n개를 만듭니다.
+func auditEvents(n int) []*domain.AuditEvent {
+	events := make([]*domain.AuditEvent, n)
+	prev := ""
+	for i := range events {
+		event := &domain.AuditEvent{
+			ID:        uint(i + 1),
+			Action:    domain.AuditLoginSucceeded,
+			IP:        "10.0.0.1",
+			Metadata:  "{}",
+			PrevHash:  prev,
+			CreatedAt: time.Date(2026, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+		event.Hash = event.ComputeHash()
+		prev = event.Hash
+		events[i] = event
+	}
+	return events
+}
+
+// verifyChain은 events를 차례로 검사한 결과를 반환합니다.
+func verifyChain(events []*domain.AuditEvent) auditChain {
+	var chain auditChain
+	for _, event := range events {
+		if !chain.add(event) {
+			break
+		}
+	}
+	return chain
+}
+
+func TestAuditChain_Valid(t *testing.T) {
+	events := auditEvents(3)
+
+	chain := verifyChain(events)
+	assert.Nil(t, chain.broken)
+	assert.Equal(t, int64(3), chain.checked)
+	assert.Equal(t, events[2].Hash, chain.lastHash)
+
+	// 이벤트가 없으면 빈 체인
+	assert.Equal(t, auditChain{}, verifyChain(nil))
+}
+
+func TestAuditChain_DetectsTampering(t *testing.T) {
+	cases := []struct {
+		name    string
+		tamper  func(events []*domain.AuditEvent) []*domain.AuditEvent
+		broken  uint
+		checked int64 // 끊기기 전까지 검사한 이벤트 수
+	}{
+		{"내용 수정", func(events []*domain.AuditEvent) []*domain.AuditEvent {
+			events[1].IP = "10.0.0.2"
+			return events
+		}, 2, 1},
+		{"수정 후 해시 재계산", func(events []*domain.AuditEvent) []*domain.AuditEvent {
+			events[1].Action = domain.AuditLoginFailed
+			events[1].Hash = events[1].ComputeHash()
+			return events // 다음 이벤트의 PrevHash가 맞지 않음
+		}, 3, 2},
+		{"중간 삭제", func(events []*domain.AuditEvent) []*domain.AuditEvent {
+			return append(events[:1], events[2:]...)
+		}, 3, 1},
+		{"첫 이벤트 삭제", func(events []*domain.AuditEvent) []*domain.AuditEvent {
+			return events[1:]
+		}, 2, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := tc.tamper(auditEvents(3))
+
+			chain := verifyChain(events)
+			if assert.NotNil(t, chain.broken) {
+				assert.Equal(t, tc.broken, chain.broken.ID)
+			}
+			assert.Equal(t, tc.checked, chain.checked)
+		})
+	}
+}
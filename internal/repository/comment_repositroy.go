@@ -1,9 +1,20 @@
 package repository
 
 import (
+	"errors"
 	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCommentCycle   = errors.New("cannot move a comment into its own subtree")
+	ErrCommentTooDeep = errors.New("comment tree exceeds max reply depth")
+
+	errCommentPathMissing = errors.New("comment path is not initialized")
 )
 
 // CommentRepository 댓글 저장소 인터페이스
@@ -14,7 +25,19 @@ type CommentRepository interface {
 	FindByPostIDWithReplies(postID uint) ([]domain.Comment, error)
 	Update(comment *domain.Comment) error
 	Delete(id uint) error
+	Restore(id uint) (*domain.Comment, error)
 	HasReplies(commentID uint) (bool, error)
+	CountVisible() (int64, error)
+
+	// 검토 대기 댓글 (관리자)
+	FindPending(pagination *dto.Pagination) ([]domain.Comment, int64, error)
+	Approve(id uint) (*domain.Comment, error)
+	Reject(id uint) error
+
+	// 트리 연산 (Materialized Path 기반, 모두 단일 트랜잭션)
+	MoveSubtree(id uint, newParentID *uint, newPostID uint, maxDepth int) (*domain.Comment, error)
+	MergeInto(sourceID, targetID uint, maxDepth int) (removed int64, err error)
+	DeleteSubtree(id uint) (removed int64, err error)
 }
 
 type commentRepository struct {
@@ -25,8 +48,29 @@ func NewCommentRepository(db *gorm.DB) CommentRepository {
 	return &commentRepository{db: db}
 }
 
+// Create 댓글 생성 - 경로 설정과 게시글의 댓글 수 증가를 같은 트랜잭션에서 처리한다.
 func (r *commentRepository) Create(comment *domain.Comment) error {
-	return r.db.Create(comment).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+
+		// ID가 생성된 후에 경로를 만들 수 있음
+		parentPath := ""
+		if comment.ParentID != nil {
+			var parent domain.Comment
+			if err := tx.Select("path").First(&parent, *comment.ParentID).Error; err != nil {
+				return err
+			}
+			parentPath = parent.Path
+		}
+		comment.Path = domain.CommentPath(parentPath, comment.ID)
+		if err := tx.Model(comment).UpdateColumn("path", comment.Path).Error; err != nil {
+			return err
+		}
+
+		return adjustCommentCount(tx, comment, 1)
+	})
 }
 
 func (r *commentRepository) FindByID(id uint) (*domain.Comment, error) {
@@ -54,9 +98,10 @@ func (r *commentRepository) FindByPostID(postID uint) ([]domain.Comment, error)
 func (r *commentRepository) FindByPostIDWithReplies(postID uint) ([]domain.Comment, error) {
 	var comments []domain.Comment
 
-	// 최상위 댓글 조회
+	// 최상위 댓글 조회 (검토 대기 댓글 제외)
 	err := r.db.
 		Where("post_id = ? AND parent_id IS NULL", postID).
+		Where("status = ?", domain.ModerationApproved).
 		Order("created_at ASC").
 		Find(&comments).Error
 	if err != nil {
@@ -74,8 +119,323 @@ func (r *commentRepository) Update(comment *domain.Comment) error {
 	return r.db.Save(comment).Error
 }
 
+// Delete 댓글 삭제 (Soft Delete) - 게시글의 댓글 수도 같은 트랜잭션에서 감소시킨다.
 func (r *commentRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Comment{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var comment domain.Comment
+		if err := tx.First(&comment, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&comment).Error; err != nil {
+			return err
+		}
+		return adjustCommentCount(tx, &comment, -1)
+	})
+}
+
+// Restore 삭제된 댓글 복구 - 게시글의 댓글 수도 같은 트랜잭션에서 증가시킨다.
+func (r *commentRepository) Restore(id uint) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("deleted_at IS NOT NULL").
+			First(&comment, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&comment).
+			UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return adjustCommentCount(tx, &comment, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.DeletedAt = gorm.DeletedAt{}
+	return &comment, nil
+}
+
+// CountVisible 공개된(검토 완료) 댓글 수 조회 - 삭제된 게시글의 댓글은 제외
+func (r *commentRepository) CountVisible() (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Comment{}).
+		Joins("JOIN posts ON posts.id = comments.post_id AND posts.deleted_at IS NULL").
+		Where("comments.status = ?", domain.ModerationApproved).
+		Count(&count).Error
+	return count, err
+}
+
+// MoveSubtree 댓글과 모든 하위 댓글을 다른 부모 아래로 이동한다.
+// newParentID가 nil이면 newPostID 게시글의 최상위 댓글이 된다. (부모가 있으면 부모의 게시글로 이동)
+func (r *commentRepository) MoveSubtree(id uint, newParentID *uint, newPostID uint, maxDepth int) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&comment, id).Error; err != nil {
+			return err
+		}
+		if comment.Path == "" {
+			return errCommentPathMissing
+		}
+
+		parentPath, postID := "", newPostID
+		if newParentID != nil {
+			var parent domain.Comment
+			if err := tx.First(&parent, *newParentID).Error; err != nil {
+				return err
+			}
+			// 자기 자신 또는 하위 댓글 아래로는 이동 불가
+			if strings.HasPrefix(parent.Path, comment.Path) {
+				return ErrCommentCycle
+			}
+			parentPath, postID = parent.Path, parent.PostID
+		}
+
+		newPath := domain.CommentPath(parentPath, comment.ID)
+		if err := checkSubtreeDepth(tx, comment.Path, comment.Depth(), domain.PathDepth(newPath), maxDepth); err != nil {
+			return err
+		}
+
+		visible, err := countVisibleIn(tx, comment.Path)
+		if err != nil {
+			return err
+		}
+
+		// 하위 댓글 경로 일괄 변경: oldPath 접두사를 newPath로 치환
+		if err := rewriteSubtree(tx, comment.Path, newPath, postID, nil); err != nil {
+			return err
+		}
+		if err := tx.Model(&comment).UpdateColumn("parent_id", newParentID).Error; err != nil {
+			return err
+		}
+
+		// 다른 게시글로 이동한 경우 댓글 수 이전
+		if postID != comment.PostID {
+			if err := adjustPostCommentCount(tx, comment.PostID, -visible); err != nil {
+				return err
+			}
+			if err := adjustPostCommentCount(tx, postID, visible); err != nil {
+				return err
+			}
+		}
+
+		comment.ParentID, comment.PostID, comment.Path = newParentID, postID, newPath
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// MergeInto source 댓글의 대댓글을 모두 target 댓글 아래로 옮기고 source 댓글은 삭제한다.
+// 삭제된 공개 댓글 수(0 또는 1)를 반환한다.
+func (r *commentRepository) MergeInto(sourceID, targetID uint, maxDepth int) (int64, error) {
+	var removed int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var source, target domain.Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&target, targetID).Error; err != nil {
+			return err
+		}
+		if source.Path == "" || target.Path == "" {
+			return errCommentPathMissing
+		}
+		// target이 source 자신이거나 source의 하위 댓글이면 병합 불가
+		if strings.HasPrefix(target.Path, source.Path) {
+			return ErrCommentCycle
+		}
+
+		// source의 자식은 target의 자식이 됨 -> 깊이는 (target 깊이 - source 깊이) 만큼 변함
+		if err := checkSubtreeDepth(tx, source.Path, source.Depth(), target.Depth(), maxDepth); err != nil {
+			return err
+		}
+
+		visible, err := countVisibleIn(tx, source.Path)
+		if err != nil {
+			return err
+		}
+		sourceVisible := int64(0)
+		if source.Status != domain.ModerationPending {
+			sourceVisible = 1
+		}
+
+		// 직계 자식의 부모 변경 후 하위 경로 일괄 변경 (source 자신은 제외)
+		if err := tx.Unscoped().Model(&domain.Comment{}).
+			Where("parent_id = ?", source.ID).
+			UpdateColumn("parent_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := rewriteSubtree(tx, source.Path, target.Path, target.PostID, &source.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+
+		// 댓글 수: source 게시글에서 전체 제거, target 게시글에 이동분 추가
+		if err := adjustPostCommentCount(tx, source.PostID, -visible); err != nil {
+			return err
+		}
+		if err := adjustPostCommentCount(tx, target.PostID, visible-sourceVisible); err != nil {
+			return err
+		}
+
+		removed = sourceVisible
+		return nil
+	})
+	return removed, err
+}
+
+// DeleteSubtree 댓글과 모든 하위 댓글을 삭제한다. (Soft Delete)
+// 삭제된 공개 댓글 수를 반환한다.
+func (r *commentRepository) DeleteSubtree(id uint) (int64, error) {
+	var removed int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var comment domain.Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&comment, id).Error; err != nil {
+			return err
+		}
+		if comment.Path == "" {
+			return errCommentPathMissing
+		}
+
+		visible, err := countVisibleIn(tx, comment.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("path LIKE ?", comment.Path+"%").
+			Delete(&domain.Comment{}).Error; err != nil {
+			return err
+		}
+
+		removed = visible
+		return adjustPostCommentCount(tx, comment.PostID, -visible)
+	})
+	return removed, err
+}
+
+// rewriteSubtree oldPrefix로 시작하는 모든 댓글(삭제된 댓글 포함)의 경로를 newPrefix로 바꾸고 게시글을 옮긴다.
+func rewriteSubtree(tx *gorm.DB, oldPrefix, newPrefix string, postID uint, excludeID *uint) error {
+	query := tx.Unscoped().Model(&domain.Comment{}).Where("path LIKE ?", oldPrefix+"%")
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	return query.UpdateColumns(map[string]any{
+		"path":    gorm.Expr("? || substr(path, ?)", newPrefix, len(oldPrefix)+1),
+		"post_id": postID,
+	}).Error
+}
+
+// checkSubtreeDepth 이동 후 가장 깊은 하위 댓글이 maxDepth를 넘지 않는지 확인한다.
+func checkSubtreeDepth(tx *gorm.DB, rootPath string, rootDepth, newRootDepth, maxDepth int) error {
+	var paths []string
+	if err := tx.Unscoped().Model(&domain.Comment{}).
+		Where("path LIKE ?", rootPath+"%").
+		Pluck("path", &paths).Error; err != nil {
+		return err
+	}
+
+	deepest := rootDepth
+	for _, p := range paths {
+		if d := domain.PathDepth(p); d > deepest {
+			deepest = d
+		}
+	}
+
+	if newRootDepth+(deepest-rootDepth) > maxDepth {
+		return ErrCommentTooDeep
+	}
+	return nil
+}
+
+// countVisibleIn 경로 아래의 공개 댓글 수
+func countVisibleIn(tx *gorm.DB, path string) (int64, error) {
+	var count int64
+	err := tx.Model(&domain.Comment{}).
+		Where("path LIKE ? AND status <> ?", path+"%", domain.ModerationPending).
+		Count(&count).Error
+	return count, err
+}
+
+// adjustCommentCount 게시글의 comment_count 갱신 (검토 대기 댓글은 집계하지 않음)
+func adjustCommentCount(tx *gorm.DB, comment *domain.Comment, delta int64) error {
+	if comment.Status == domain.ModerationPending {
+		return nil
+	}
+	return adjustPostCommentCount(tx, comment.PostID, delta)
+}
+
+func adjustPostCommentCount(tx *gorm.DB, postID uint, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+	return tx.Unscoped().Model(&domain.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("comment_count", gorm.Expr("comment_count + ?", delta)).
+		Error
+}
+
+// FindPending 검토 대기 댓글 목록 (오래된 순)
+func (r *commentRepository) FindPending(pagination *dto.Pagination) ([]domain.Comment, int64, error) {
+	var comments []domain.Comment
+	var total int64
+
+	query := r.db.Model(&domain.Comment{}).Where("status = ?", domain.ModerationPending)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at ASC, id ASC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Size).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// Approve 검토 대기 댓글 공개 - 게시글의 댓글 수도 같은 트랜잭션에서 증가시킨다.
+// 대기 상태가 아니면 ErrRecordNotFound
+func (r *commentRepository) Approve(id uint) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Comment{}).
+			Where("id = ? AND status = ?", id, domain.ModerationPending).
+			Update("status", domain.ModerationApproved)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.First(&comment, id).Error; err != nil {
+			return err
+		}
+		return adjustCommentCount(tx, &comment, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Reject 검토 대기 댓글 삭제 (Soft Delete) - 대기 댓글은 댓글 수에 없으므로 갱신하지 않음
+// 대기 상태가 아니면 ErrRecordNotFound
+func (r *commentRepository) Reject(id uint) error {
+	result := r.db.Where("status = ?", domain.ModerationPending).Delete(&domain.Comment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func (r *commentRepository) HasReplies(commentID uint) (bool, error) {
@@ -90,6 +450,7 @@ func (r *commentRepository) loadReplies(comment *domain.Comment) {
 	var replies []domain.Comment
 	r.db.
 		Where("parent_id = ?", comment.ID).
+		Where("status = ?", domain.ModerationApproved).
 		Order("created_at ASC").
 		Find(&replies)
 
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type LoginEventRepository interface {
+	Create(ctx context.Context, event *domain.LoginEvent) error
+	// ListByUser는 사용자의 로그인 기록을 최신순으로 조회합니다. (전체 개수 포함)
+	ListByUser(ctx context.Context, userID uint, pagination *dto.Pagination) ([]domain.LoginEvent, int64, error)
+	// CheckDevice는 이전에 성공한 로그인 중 같은 User-Agent, 같은 IP 대역이 있었는지 확인합니다.
+	CheckDevice(ctx context.Context, userID uint, userAgent, ipRange string) (*DeviceHistory, error)
+}
+
+// DeviceHistory 이전 로그인 성공 기록과 비교한 결과
+type DeviceHistory struct {
+	HasHistory bool // 로그인에 성공한 적이 있음 (처음 로그인이면 새 기기로 보지 않음)
+	SeenAgent  bool // 같은 User-Agent
+	SeenRange  bool // 같은 IP 대역
+}
+
+type loginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) LoginEventRepository {
+	return &loginEventRepository{db: db}
+}
+
+func (r *loginEventRepository) Create(ctx context.Context, event *domain.LoginEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *loginEventRepository) ListByUser(ctx context.Context, userID uint, pagination *dto.Pagination) ([]domain.LoginEvent, int64, error) {
+	var events []domain.LoginEvent
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.LoginEvent{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Size).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (r *loginEventRepository) CheckDevice(ctx context.Context, userID uint, userAgent, ipRange string) (*DeviceHistory, error) {
+	var history DeviceHistory
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginEvent{}).
+		Select(`COUNT(*) > 0 AS has_history,
+			COALESCE(BOOL_OR(user_agent = ?), false) AS seen_agent,
+			COALESCE(BOOL_OR(ip_range = ?), false) AS seen_range`, userAgent, ipRange).
+		Where("user_id = ? AND success = ?", userID, true).
+		Scan(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
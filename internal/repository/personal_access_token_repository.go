@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPersonalTokenNotFound = errors.New("personal access token not found")
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *domain.PersonalAccessToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error)
+	ListByUser(ctx context.Context, userID uint) ([]domain.PersonalAccessToken, error)
+	// Delete는 사용자의 토큰을 삭제합니다. 다른 사용자의 토큰이면 ErrPersonalTokenNotFound를 반환합니다.
+	Delete(ctx context.Context, userID, id uint) error
+	DeleteAllByUser(ctx context.Context, userID uint) error
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db}
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *personalAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonalTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *personalAccessTokenRepository) ListByUser(ctx context.Context, userID uint) ([]domain.PersonalAccessToken, error) {
+	var tokens []domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *personalAccessTokenRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&domain.PersonalAccessToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPersonalTokenNotFound
+	}
+	return nil
+}
+
+func (r *personalAccessTokenRepository) DeleteAllByUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&domain.PersonalAccessToken{}).Error
+}
+
+func (r *personalAccessTokenRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
@@ -21,6 +21,12 @@ type PostRepository interface {
 	Delete(id uint) error
 	IncrementViews(id uint) error
 	FindAllByCursor(cursor *dto.Cursor, limit int) ([]domain.Post, error)
+	CountVisible() (int64, error)
+
+	// 검토 대기 게시글 (관리자)
+	FindPending(pagination *dto.Pagination) ([]domain.Post, int64, error)
+	Approve(id uint) (*domain.Post, error)
+	Reject(id uint) error
 }
 
 // postRepository PostRepository 구현체
@@ -41,7 +47,7 @@ func (r *postRepository) Create(post *domain.Post) error {
 // FindByID ID로 게시글 조회
 func (r *postRepository) FindByID(id uint) (*domain.Post, error) {
 	var post domain.Post
-	err := r.db.First(&post, id).Error
+	err := r.db.Preload("Author").First(&post, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +60,8 @@ func (r *postRepository) FindAll(pagination *dto.Pagination, search *dto.SearchP
 	var posts []domain.Post
 	var total int64
 
-	query := r.db.Model(&domain.Post{})
+	// 검토 대기 중인 게시글은 목록에서 제외
+	query := r.db.Model(&domain.Post{}).Where("status = ?", domain.ModerationApproved)
 
 	// 검색 조건 적용
 	/**
@@ -90,7 +97,8 @@ func (r *postRepository) FindAll(pagination *dto.Pagination, search *dto.SearchP
 	}
 
 	// 페이징 적용하여 조회
-	err := r.db.
+	err := query.
+		Preload("Author").
 		Order(orderStr).
 		Offset(pagination.Offset()).
 		Limit(pagination.Size).
@@ -107,7 +115,9 @@ func (r *postRepository) FindAll(pagination *dto.Pagination, search *dto.SearchP
 func (r *postRepository) FindAllByCursor(cursor *dto.Cursor, limit int) ([]domain.Post, error) {
 	var posts []domain.Post
 
-	query := r.db.Order("created_at DESC, id DESC")
+	query := r.db.
+		Where("status = ?", domain.ModerationApproved).
+		Order("created_at DESC, id DESC")
 
 	// 커서가 있으면 조건 추가
 	if cursor != nil {
@@ -117,7 +127,7 @@ func (r *postRepository) FindAllByCursor(cursor *dto.Cursor, limit int) ([]domai
 		)
 	}
 
-	err := query.Limit(limit + 1).Find(&posts).Error // 1개 더 조회해서 다음 페이지 존재 확인
+	err := query.Preload("Author").Limit(limit + 1).Find(&posts).Error // 1개 더 조회해서 다음 페이지 존재 확인
 	if err != nil {
 		return nil, err
 	}
@@ -125,9 +135,9 @@ func (r *postRepository) FindAllByCursor(cursor *dto.Cursor, limit int) ([]domai
 	return posts, nil
 }
 
-// Update 게시글 수정
+// Update 게시글 수정 (comment_count는 댓글 트랜잭션에서만 갱신)
 func (r *postRepository) Update(post *domain.Post) error {
-	return r.db.Save(post).Error
+	return r.db.Omit("comment_count").Save(post).Error
 }
 
 // Delete 게시글 삭제
@@ -142,3 +152,60 @@ func (r *postRepository) IncrementViews(id uint) error {
 		UpdateColumn("views", gorm.Expr("views + 1")).
 		Error
 }
+
+// CountVisible 공개된(검토 완료) 게시글 수 조회
+func (r *postRepository) CountVisible() (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Post{}).
+		Where("status = ?", domain.ModerationApproved).
+		Count(&count).Error
+	return count, err
+}
+
+// FindPending 검토 대기 게시글 목록 (오래된 순)
+func (r *postRepository) FindPending(pagination *dto.Pagination) ([]domain.Post, int64, error) {
+	var posts []domain.Post
+	var total int64
+
+	query := r.db.Model(&domain.Post{}).Where("status = ?", domain.ModerationPending)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Author").
+		Order("created_at ASC, id ASC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Size).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+// Approve 검토 대기 게시글 공개 - 대기 상태가 아니면 ErrRecordNotFound
+func (r *postRepository) Approve(id uint) (*domain.Post, error) {
+	result := r.db.Model(&domain.Post{}).
+		Where("id = ? AND status = ?", id, domain.ModerationPending).
+		Update("status", domain.ModerationApproved)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.FindByID(id)
+}
+
+// Reject 검토 대기 게시글 삭제 (Soft Delete) - 대기 상태가 아니면 ErrRecordNotFound
+func (r *postRepository) Reject(id uint) error {
+	result := r.db.Where("status = ?", domain.ModerationPending).Delete(&domain.Post{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
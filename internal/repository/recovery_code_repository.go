@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRecoveryCodeInvalid = errors.New("recovery code is invalid or already used")
+)
+
+type RecoveryCodeRepository interface {
+	// ReplaceAll은 사용자의 복구 코드를 모두 지우고 새 코드로 교체합니다.
+	ReplaceAll(ctx context.Context, userID uint, codeHashes []string) error
+	// Consume은 사용하지 않은 복구 코드를 사용 처리합니다. 없으면 ErrRecoveryCodeInvalid
+	Consume(ctx context.Context, userID uint, codeHash string) error
+	// DeleteAll은 사용자의 복구 코드를 모두 삭제합니다. (2단계 인증 해제)
+	DeleteAll(ctx context.Context, userID uint) error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+func (r *recoveryCodeRepository) ReplaceAll(ctx context.Context, userID uint, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.RecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = domain.RecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *recoveryCodeRepository) Consume(ctx context.Context, userID uint, codeHash string) error {
+	// 조건부 UPDATE 한 번으로 처리 - 동시에 같은 코드를 두 번 쓸 수 없음
+	result := r.db.WithContext(ctx).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecoveryCodeInvalid
+	}
+	return nil
+}
+
+func (r *recoveryCodeRepository) DeleteAll(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
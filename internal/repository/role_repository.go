@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+)
+
+type RoleRepository interface {
+	// Create는 역할을 권한과 함께 생성합니다.
+	Create(ctx context.Context, role *domain.RoleDefinition) error
+	FindByName(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error)
+	List(ctx context.Context) ([]*domain.RoleDefinition, error)
+	// Update는 역할 정보를 수정하고 권한 목록을 role.Permissions로 교체합니다.
+	Update(ctx context.Context, role *domain.RoleDefinition) error
+	Delete(ctx context.Context, id uint) error
+	ExistsByName(ctx context.Context, name domain.Role) (bool, error)
+	// CountUsers는 역할이 지정된 사용자 수를 반환합니다.
+	CountUsers(ctx context.Context, name domain.Role) (int64, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *domain.RoleDefinition) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error) {
+	var role domain.RoleDefinition
+	err := r.db.WithContext(ctx).
+		Preload("Permissions").
+		Where("name = ?", name).
+		First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*domain.RoleDefinition, error) {
+	var roles []*domain.RoleDefinition
+	err := r.db.WithContext(ctx).
+		Preload("Permissions").
+		Order("id ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *domain.RoleDefinition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).
+			Select("description", "updated_at").
+			Updates(role).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		for i := range role.Permissions {
+			role.Permissions[i].RoleID = role.ID
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.RoleDefinition{}, id).Error
+	})
+}
+
+func (r *roleRepository) ExistsByName(ctx context.Context, name domain.Role) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.RoleDefinition{}).
+		Where("name = ?", name).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *roleRepository) CountUsers(ctx context.Context, name domain.Role) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("role = ?", name).
+		Count(&count).Error
+	return count, err
+}
@@ -3,7 +3,10 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -20,6 +23,24 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uint) error
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	// Search는 관리자 사용자 목록을 검색 조건으로 조회합니다. (전체 개수 포함)
+	Search(ctx context.Context, params *dto.UserSearchParams, pagination *dto.Pagination) ([]*domain.User, int64, error)
+	// Stats는 사용자 통계를 집계합니다. since 이후 가입자를 신규로 셉니다.
+	Stats(ctx context.Context, since time.Time) (*UserStats, error)
+	// FindDeletionDue는 탈퇴 유예 기간이 끝난 사용자를 최대 limit명 조회합니다.
+	FindDeletionDue(ctx context.Context, now time.Time, limit int) ([]*domain.User, error)
+	// Anonymize는 탈퇴한 사용자의 개인정보를 지우고 삭제합니다.
+	// 게시글/댓글은 남기고 작성자만 익명으로 바꿉니다.
+	Anonymize(ctx context.Context, id uint) error
+}
+
+// UserStats 사용자 통계
+type UserStats struct {
+	Total      int64
+	Suspended  int64
+	Unverified int64
+	NewSince   int64
+	ByRole     map[domain.Role]int64
 }
 
 type userRepository struct {
@@ -89,3 +110,155 @@ func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool,
 		Count(&count).Error
 	return count > 0, err
 }
+
+func (r *userRepository) Search(ctx context.Context, params *dto.UserSearchParams, pagination *dto.Pagination) ([]*domain.User, int64, error) {
+	var users []*domain.User
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+
+	if params.Query != "" {
+		q := "%" + params.Query + "%"
+		query = query.Where("email ILIKE ? OR username ILIKE ?", q, q)
+	}
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+
+	now := time.Now()
+	suspended := "suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?)"
+	switch params.Status {
+	case dto.UserStatusActive:
+		query = query.Where("NOT ("+suspended+")", now)
+	case dto.UserStatusSuspended:
+		query = query.Where(suspended, now)
+	case dto.UserStatusUnverified:
+		query = query.Where("email_verified_at IS NULL")
+	}
+
+	// 날짜 범위 (To는 해당 날짜 포함)
+	if params.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedTo != nil {
+		query = query.Where("created_at < ?", params.CreatedTo.AddDate(0, 0, 1))
+	}
+	if params.LastLoginFrom != nil {
+		query = query.Where("last_login_at >= ?", *params.LastLoginFrom)
+	}
+	if params.LastLoginTo != nil {
+		query = query.Where("last_login_at < ?", params.LastLoginTo.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order(params.ToOrderString()).
+		Offset(pagination.Offset()).
+		Limit(pagination.Size).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *userRepository) Stats(ctx context.Context, since time.Time) (*UserStats, error) {
+	var row struct {
+		Total      int64
+		Suspended  int64
+		Unverified int64
+		NewSince   int64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.User{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?)) AS suspended,
+			COUNT(*) FILTER (WHERE email_verified_at IS NULL) AS unverified,
+			COUNT(*) FILTER (WHERE created_at >= ?) AS new_since`, time.Now(), since).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []struct {
+		Role  domain.Role
+		Count int64
+	}
+	err = r.db.WithContext(ctx).Model(&domain.User{}).
+		Select("role, COUNT(*) AS count").
+		Group("role").
+		Scan(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &UserStats{
+		Total:      row.Total,
+		Suspended:  row.Suspended,
+		Unverified: row.Unverified,
+		NewSince:   row.NewSince,
+		ByRole:     make(map[domain.Role]int64, len(roles)),
+	}
+	for _, role := range roles {
+		stats.ByRole[role.Role] = role.Count
+	}
+	return stats, nil
+}
+
+func (r *userRepository) FindDeletionDue(ctx context.Context, now time.Time, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.db.WithContext(ctx).
+		Where("deletion_due_at <= ?", now).
+		Order("deletion_due_at ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 댓글 - 삭제된 댓글도 복구될 수 있으므로 함께 익명화
+		err := tx.Unscoped().Model(&domain.Comment{}).
+			Where("author_id = ?", id).
+			Updates(map[string]any{"author": domain.DeletedUsername, "author_id": nil}).Error
+		if err != nil {
+			return err
+		}
+
+		// 로그인 수단, 토큰, 로그인 기록 (IP/기기 정보)
+		for _, model := range []any{
+			&domain.UserIdentity{},
+			&domain.PersonalAccessToken{},
+			&domain.UserToken{},
+			&domain.RecoveryCode{},
+			&domain.LoginEvent{},
+		} {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		// 게시글은 author_id를 유지하고, 사용자 정보만 지운다. (이메일은 재가입할 수 있도록 비움)
+		result := tx.Model(&domain.User{}).Where("id = ?", id).Updates(map[string]any{
+			"email":           fmt.Sprintf("deleted-%d@deleted.invalid", id),
+			"username":        domain.DeletedUsername,
+			"password":        "",
+			"bio":             "",
+			"avatar_url":      "",
+			"totp_secret":     "",
+			"totp_enabled_at": nil,
+			"deletion_due_at": nil,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		return tx.Delete(&domain.User{}, id).Error
+	})
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUserTokenInvalid = errors.New("token is invalid, used or expired")
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *domain.UserToken) error
+	// Find는 사용 가능한 토큰을 사용 처리하지 않고 조회합니다.
+	// 없거나 이미 사용/만료된 토큰이면 ErrUserTokenInvalid를 반환합니다.
+	Find(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error)
+	// Consume은 사용 가능한 토큰을 사용 처리하고 반환합니다.
+	// 없거나 이미 사용/만료된 토큰이면 ErrUserTokenInvalid를 반환합니다.
+	Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error)
+	// InvalidateAll은 사용자의 미사용 토큰을 모두 사용 처리합니다. (재발송 시 이전 토큰 무효화)
+	InvalidateAll(ctx context.Context, userID uint, purpose domain.TokenPurpose) error
+	// CountSince는 since 이후 발급된 토큰 수를 반환합니다. (발송 횟수 제한용)
+	CountSince(ctx context.Context, userID uint, purpose domain.TokenPurpose, since time.Time) (int64, error)
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *userTokenRepository) Find(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserTokenInvalid
+		}
+		return nil, err
+	}
+
+	if !token.IsUsable(time.Now()) {
+		return nil, ErrUserTokenInvalid
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 동시에 같은 토큰을 두 번 사용하지 못하도록 잠금
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+			First(&token).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserTokenInvalid
+			}
+			return err
+		}
+
+		now := time.Now()
+		if !token.IsUsable(now) {
+			return ErrUserTokenInvalid
+		}
+
+		token.UsedAt = &now
+		return tx.Model(&token).Update("used_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) InvalidateAll(ctx context.Context, userID uint, purpose domain.TokenPurpose) error {
+	return r.db.WithContext(ctx).Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *userTokenRepository) CountSince(ctx context.Context, userID uint, purpose domain.TokenPurpose, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at >= ?", userID, purpose, since).
+		Count(&count).Error
+	return count, err
+}
@@ -2,11 +2,11 @@ package router
 
 import (
 	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
 	"gorm-test/internal/handler"
 	"gorm-test/middleware"
 
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/api"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -16,54 +16,161 @@ type Router struct {
 	postHandler    *handler.PostHandler
 	commentHandler *handler.CommentHandler
 	authHandler    *handler.AuthHandler
+	oidcHandler    *handler.OIDCHandler
+	roleHandler    *handler.RoleHandler
+	adminHandler   *handler.AdminUserHandler
+	userHandler    *handler.UserHandler
+	auditHandler   *handler.AuditHandler
+	oauthHandler   *handler.OAuthHandler
 }
 
 // NewRouter 생성자
 func NewRouter(postHandler *handler.PostHandler, commentHandler *handler.CommentHandler, authHandler *handler.AuthHandler,
+	oidcHandler *handler.OIDCHandler, roleHandler *handler.RoleHandler, adminHandler *handler.AdminUserHandler,
+	userHandler *handler.UserHandler, auditHandler *handler.AuditHandler, oauthHandler *handler.OAuthHandler,
 ) *Router {
 	return &Router{
 		engine:         gin.Default(),
 		postHandler:    postHandler,
 		commentHandler: commentHandler,
+		authHandler:    authHandler,
+		oidcHandler:    oidcHandler,
+		roleHandler:    roleHandler,
+		adminHandler:   adminHandler,
+		userHandler:    userHandler,
+		auditHandler:   auditHandler,
+		oauthHandler:   oauthHandler,
 	}
 }
 
+// SetTrustedProxies 클라이언트 IP를 전달하는 프록시 설정
+// 믿을 수 있는 프록시를 거친 요청만 X-Forwarded-For를 사용하므로, 클라이언트가 IP를 위조해서 IP 기준 한도를 피할 수 없습니다.
+// proxies가 비어 있으면 연결 주소를 클라이언트 IP로 사용합니다.
+func (r *Router) SetTrustedProxies(proxies []string, platform string) error {
+	if err := r.engine.SetTrustedProxies(proxies); err != nil {
+		return err
+	}
+	r.engine.TrustedPlatform = platform
+	return nil
+}
+
 // Use 전역 미들웨어 등록
 func (r *Router) Use(middleware ...gin.HandlerFunc) {
 	r.engine.Use(middleware...)
 }
 
 // Setup 라우트 설정
-func (r *Router) Setup(tokenService *auth.TokenService) *gin.Engine {
+// permissions는 관리자 API의 역할별 권한 확인에 사용합니다.
+// requireAdmin2FA면 관리자 API는 2단계 인증을 거친 세션만 사용할 수 있습니다.
+func (r *Router) Setup(tokenService *auth.TokenService, permissions auth.PermissionChecker, clients *auth.ClientCredentials,
+	limits *middleware.RateLimits, requireAdmin2FA bool,
+) *gin.Engine {
 	// API 버전 그룹
 
 	v1 := r.engine.Group("/api/v1")
 
 	{
 
-		//관리자 라우트
+		//관리자 라우트 (API별 권한은 역할에 부여된 permission으로 확인)
 		admin := v1.Group("/admin")
 		admin.Use(middleware.AuthMiddleware(tokenService))
-		admin.Use(middleware.RequireRole("admin"))
-		{
-			admin.GET("/users", r.authHandler.Signup)
-			admin.DELETE("/users/:id", r.authHandler.Signup)
-			admin.GET("/stats", r.authHandler.Signup)
+		admin.Use(middleware.RequireScope(auth.ScopeAdmin))
+		admin.Use(middleware.DenyImpersonation()) // 대리 로그인 토큰으로는 관리자 API 사용 불가
+		if requireAdmin2FA {
+			admin.Use(middleware.RequireTwoFactor()) // 2단계 인증을 거친 세션만
 		}
 		{
+			can := func(p ...domain.Permission) gin.HandlerFunc {
+				return middleware.RequirePermission(permissions, p...)
+			}
+
+			admin.GET("/users", can(domain.PermUserRead), r.adminHandler.List)
+			admin.GET("/users/:id", can(domain.PermUserRead), r.adminHandler.Get)
+			admin.DELETE("/users/:id", can(domain.PermUserDelete), r.adminHandler.Delete)
+			admin.POST("/users/:id/suspend", can(domain.PermUserBan), r.adminHandler.Suspend)
+			admin.DELETE("/users/:id/suspend", can(domain.PermUserBan), r.adminHandler.Unsuspend)
+			admin.POST("/users/:id/logout", can(domain.PermUserLogout), r.adminHandler.ForceLogout)
+			admin.POST("/users/:id/unlock", can(domain.PermUserUnlock), r.authHandler.UnlockAccount)
+			admin.PUT("/users/:id/role", can(domain.PermRoleAssign), r.roleHandler.AssignRole)
+			admin.POST("/users/:id/impersonate", can(domain.PermUserImpersonate), r.adminHandler.Impersonate)
+			admin.GET("/stats", can(domain.PermStatsRead), r.adminHandler.Stats)
+
+			// 콘텐츠 필터가 보류한 게시글/댓글 검토
+			admin.GET("/posts/pending", can(domain.PermPostModerate), r.postHandler.ListPending)
+			admin.POST("/posts/:postId/approve", can(domain.PermPostModerate), r.postHandler.Approve)
+			admin.POST("/posts/:postId/reject", can(domain.PermPostModerate), r.postHandler.Reject)
+			admin.GET("/comments/pending", can(domain.PermCommentModerate), r.commentHandler.ListPending)
+			admin.POST("/comments/:commentId/approve", can(domain.PermCommentModerate), r.commentHandler.Approve)
+			admin.POST("/comments/:commentId/reject", can(domain.PermCommentModerate), r.commentHandler.Reject)
+
+			admin.POST("/comments/:commentId/restore", can(domain.PermCommentModerate), r.commentHandler.Restore)
+			admin.POST("/comments/:commentId/move", can(domain.PermCommentModerate), r.commentHandler.Move)
+			admin.POST("/comments/:commentId/merge", can(domain.PermCommentModerate), r.commentHandler.Merge)
+			admin.DELETE("/comments/:commentId/tree", can(domain.PermCommentModerate), r.commentHandler.DeleteTree)
+
+			// 역할/권한 관리
+			admin.GET("/permissions", can(domain.PermRoleManage), r.roleHandler.ListPermissions)
+			admin.GET("/roles", can(domain.PermRoleManage), r.roleHandler.List)
+			admin.POST("/roles", can(domain.PermRoleManage), r.roleHandler.Create)
+			admin.PUT("/roles/:name", can(domain.PermRoleManage), r.roleHandler.Update)
+			admin.DELETE("/roles/:name", can(domain.PermRoleManage), r.roleHandler.Delete)
+
+			// 감사 로그
+			admin.GET("/audit-events", can(domain.PermAuditRead), r.auditHandler.List)
+			admin.GET("/audit-events/export", can(domain.PermAuditRead), r.auditHandler.Export)
+			admin.GET("/audit-events/verify", can(domain.PermAuditRead), r.auditHandler.Verify)
+		}
 
 		// 인증 라우트
-			authGroup := v1.Group("/api/auths")
+		authGroup := v1.Group("/api/auths")
 		{
 			authGroup.POST("/signup", r.authHandler.Signup)
-			authGroup.POST("/login", r.authHandler.Login)
+			authGroup.POST("/login", limits.For("login"), r.authHandler.Login)
 			authGroup.POST("/refresh", r.authHandler.RefreshToken)
-			authGroup.POST("/logout", middleware.AuthMiddleware(tokenService, tokenStore), authHandler.Logout)
+			authGroup.POST("/verify", r.authHandler.VerifyEmail)
+			authGroup.POST("/resend-verification", limits.For("email"), r.authHandler.ResendVerification)
+			authGroup.POST("/2fa/verify", limits.For("two_factor"), r.authHandler.VerifyTwoFactor) // 코드 무차별 대입 방지
+			// 외부 IdP 로그인 (OIDC)
+			authGroup.GET("/oidc/:provider/login", r.oidcHandler.Login)
+			authGroup.GET("/oidc/:provider/callback", r.oidcHandler.Callback)
+			authGroup.POST("/password/forgot", limits.For("password_reset"), r.authHandler.ForgotPassword) // 계정당 한도는 서비스에서
+			authGroup.POST("/password/reset", limits.For("password_reset"), r.authHandler.ResetPassword)
+			authGroup.POST("/logout", middleware.AuthMiddleware(tokenService), r.authHandler.Logout)
+		}
+
+		// 내 계정 라우트
+		me := v1.Group("/me")
+		me.Use(middleware.AuthMiddleware(tokenService))
+		me.Use(middleware.RequireSessionAuth()) // 계정 관리는 Personal Access Token으로 불가
+		{
+			self := middleware.DenyImpersonation() // 본인만 할 수 있는 작업 (대리 로그인 불가)
+
+			// 프로필/비밀번호/탈퇴
+			me.GET("", r.userHandler.GetProfile)
+			me.PATCH("", self, r.userHandler.UpdateProfile)
+			me.DELETE("", self, r.userHandler.DeleteAccount)
+			me.DELETE("/deletion", self, r.userHandler.CancelDeletion) // 유예 기간 중 탈퇴 취소
+			me.POST("/password", self, r.userHandler.ChangePassword)
+			me.GET("/logins", r.userHandler.ListLogins) // 로그인 기록
+
+			me.GET("/sessions", r.authHandler.ListSessions)
+			me.DELETE("/sessions", self, r.authHandler.RevokeOtherSessions) // 현재 기기 제외 전체 로그아웃
+			me.DELETE("/sessions/:id", self, r.authHandler.RevokeSession)
+
+			// 2단계 인증 (TOTP)
+			me.POST("/2fa/setup", self, r.authHandler.SetupTwoFactor)
+			me.POST("/2fa/confirm", self, r.authHandler.ConfirmTwoFactor)
+			me.POST("/2fa/disable", self, r.authHandler.DisableTwoFactor)
+
+			// Personal Access Token
+			me.GET("/tokens", r.authHandler.ListPersonalTokens)
+			me.POST("/tokens", self, r.authHandler.CreatePersonalToken)
+			me.DELETE("/tokens/:id", self, r.authHandler.RevokePersonalToken)
 		}
 
 		// 게시글 라우트 (비인증)
 		postsPublic := v1.Group("/posts")
-		postsPublic.Use(middleware.RateLimiter(5.0/60.0, 5)) // posts 는 분당 5회 Rate Limit 적용
+		postsPublic.Use(limits.For("posts_public"))
 		{
 			// 댓글 라우트
 			postsPublic.GET("/:postId/comments", r.commentHandler.GetByPostID)
@@ -73,27 +180,45 @@ func (r *Router) Setup(tokenService *auth.TokenService) *gin.Engine {
 		postsOptional.Use(middleware.OptionalAuthMiddleware(tokenService))
 		{
 			postsOptional.GET("", r.postHandler.GetList)
-			postsOptional.GET("/:id", r.postHandler.GetByID)
+			postsOptional.GET("/:postId", r.postHandler.GetByID)
 		}
 		// 게시글 라우트 (인증)
 		postsProtected := v1.Group("/posts")
 		postsProtected.Use(middleware.AuthMiddleware(tokenService))
+		postsProtected.Use(middleware.RequireVerifiedEmail()) // 이메일 미인증 사용자는 읽기 전용
+		postsProtected.Use(limits.For("api"))                 // 사용자/Personal Access Token별
 
 		{
-			postsProtected.POST("", r.postHandler.Create)
-			postsProtected.PUT("/:postId", r.postHandler.Update)
-			postsProtected.DELETE("/:postId", r.postHandler.Delete)
-			postsProtected.GET("/cursor", r.postHandler.GetListByCursor)
+			postsRead := middleware.RequireScope(auth.ScopePostsRead)
+			postsWrite := middleware.RequireScope(auth.ScopePostsWrite)
+			commentsWrite := middleware.RequireScope(auth.ScopeCommentsWrite)
+
+			postsProtected.POST("", postsWrite, r.postHandler.Create)
+			postsProtected.PUT("/:postId", postsWrite, r.postHandler.Update)
+			postsProtected.DELETE("/:postId", postsWrite, r.postHandler.Delete)
+			postsProtected.PUT("/:postId/comment-lock", postsWrite, r.postHandler.LockComments)
+			postsProtected.GET("/cursor", postsRead, r.postHandler.GetListByCursor)
 			// 댓글 라우트
-			postsProtected.POST("/:postId/comments", r.commentHandler.Create)
-			postsProtected.PUT("/:postId/comments/:commentId", r.commentHandler.Update)
-			postsProtected.DELETE("/:postId/comments/:commentId", r.commentHandler.Delete)
+			postsProtected.POST("/:postId/comments", commentsWrite, r.commentHandler.Create)
+			postsProtected.PUT("/:postId/comments/:commentId", commentsWrite, r.commentHandler.Update)
+			postsProtected.DELETE("/:postId/comments/:commentId", commentsWrite, r.commentHandler.Delete)
 		}
 	}
 
 	// 메트릭 엔드포인트 (인증 없이 접근 가능)
 	r.engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// 토큰 검증용 공개키 (다른 서비스가 Access Token을 직접 검증)
+	r.engine.GET("/.well-known/jwks.json", r.authHandler.JWKS)
+
+	// 토큰 확인/폐기 (RFC 7662, RFC 7009) - 등록된 내부 서비스만 (client_id/client_secret)
+	oauthGroup := r.engine.Group("/oauth")
+	oauthGroup.Use(middleware.ClientAuth(clients))
+	{
+		oauthGroup.POST("/introspect", r.oauthHandler.Introspect)
+		oauthGroup.POST("/revoke", r.oauthHandler.Revoke)
+	}
+
 	// 헬스 체크
 	r.engine.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// impersonationTTL 대리 로그인 토큰 유효 시간
+const impersonationTTL = 15 * time.Minute
+
+// AdminUserService는 관리자의 사용자 관리(조회, 이용 정지, 강제 로그아웃, 삭제)를 담당합니다.
+//
+//	자신보다 권한이 많은 역할의 사용자와 자기 자신은 관리할 수 없습니다.
+type AdminUserService struct {
+	userRepo          repository.UserRepository
+	personalTokenRepo repository.PersonalAccessTokenRepository
+	tokenService      *auth.TokenService
+	roleService       *RoleService
+	audit             *AuditService
+}
+
+func NewAdminUserService(userRepo repository.UserRepository, personalTokenRepo repository.PersonalAccessTokenRepository,
+	tokenService *auth.TokenService, roleService *RoleService, audit *AuditService,
+) *AdminUserService {
+	return &AdminUserService{
+		userRepo:          userRepo,
+		personalTokenRepo: personalTokenRepo,
+		tokenService:      tokenService,
+		roleService:       roleService,
+		audit:             audit,
+	}
+}
+
+// List 사용자 검색
+func (s *AdminUserService) List(ctx context.Context, params *dto.UserSearchParams, pagination *dto.Pagination) ([]*dto.AdminUserResponse, *dto.Meta, error) {
+	users, total, err := s.userRepo.Search(ctx, params, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := make([]*dto.AdminUserResponse, len(users))
+	for i, user := range users {
+		list[i] = adminUserResponse(user)
+	}
+
+	meta := &dto.Meta{
+		Page:       pagination.Page,
+		Size:       pagination.Size,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Size))),
+	}
+	return list, meta, nil
+}
+
+// Get 사용자 상세 (활성 세션, Personal Access Token 수 포함)
+func (s *AdminUserService) Get(ctx context.Context, id uint) (*dto.AdminUserDetailResponse, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.tokenService.ListSessions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.personalTokenRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdminUserDetailResponse{
+		AdminUserResponse:  *adminUserResponse(user),
+		Sessions:           make([]dto.SessionResponse, 0, len(sessions)),
+		PersonalTokenCount: len(tokens),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, dto.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+		})
+	}
+	return resp, nil
+}
+
+// Suspend 이용 정지 - until이 없으면 영구 정지(ban)
+// 정지와 함께 모든 세션을 종료합니다. (Personal Access Token은 정지 중 사용 불가)
+func (s *AdminUserService) Suspend(ctx context.Context, id uint, req *dto.SuspendUserRequest) (*dto.AdminUserResponse, error) {
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		return nil, apperror.ValidationError(map[string]string{
+			"until": "정지 종료 시각은 현재 이후여야 합니다",
+		})
+	}
+
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SuspendedAt = &now
+	user.SuspendedUntil = req.Until
+	user.SuspendReason = req.Reason
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenService.RevokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user suspended", "user_id", user.ID, "by", actorID(ctx), "until", req.Until, "reason", req.Reason)
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditUserSuspended,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"reason": req.Reason, "until": req.Until},
+	})
+	return adminUserResponse(user), nil
+}
+
+// Unsuspend 이용 정지 해제
+func (s *AdminUserService) Unsuspend(ctx context.Context, id uint) (*dto.AdminUserResponse, error) {
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspendReason = ""
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user unsuspended", "user_id", user.ID, "by", actorID(ctx))
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditUserUnsuspended, TargetID: &user.ID})
+	return adminUserResponse(user), nil
+}
+
+// ForceLogout 강제 로그아웃 - 모든 세션과 Personal Access Token을 폐기합니다.
+func (s *AdminUserService) ForceLogout(ctx context.Context, id uint) error {
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.revokeAllTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "user force logged out", "user_id", user.ID, "by", actorID(ctx))
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditUserForceLoggedOut, TargetID: &user.ID})
+	return nil
+}
+
+// Delete 사용자 삭제 (soft delete) - 모든 토큰도 폐기합니다.
+func (s *AdminUserService) Delete(ctx context.Context, id uint) error {
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.revokeAllTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "user deleted", "user_id", user.ID, "by", actorID(ctx))
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditUserDeleted,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"email": user.Email},
+	})
+	return nil
+}
+
+// Impersonate 대리 로그인 - 대상 사용자로 요청하는 짧은 Access Token을 발급합니다.
+// 토큰에는 관리자 정보(act)가 담기고, 비밀번호 변경/토큰 발급 등 본인만 할 수 있는 작업은 막힙니다.
+func (s *AdminUserService) Impersonate(ctx context.Context, id uint) (*dto.ImpersonationResponse, error) {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, apperror.Unauthorized("인증이 필요합니다")
+	}
+	if claims.IsPersonalToken() || claims.IsImpersonated() {
+		return nil, apperror.Forbidden("로그인한 관리자 세션에서만 대리 로그인할 수 있습니다")
+	}
+
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := auth.TokenSubject{
+		UserID:        user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		Role:          string(user.Role),
+		EmailVerified: user.IsEmailVerified(),
+	}
+	actor := auth.ActorClaim{
+		Subject: claims.Subject,
+		UserID:  claims.UserID,
+		Email:   claims.Email,
+	}
+	accessToken, err := s.tokenService.GenerateImpersonationToken(subject, actor, claims.SessionID, impersonationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "impersonation started", "user_id", user.ID, "by", claims.UserID, "session_id", claims.SessionID)
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditUserImpersonated,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"session_id": claims.SessionID, "expires_in": int64(impersonationTTL.Seconds())},
+	})
+	return &dto.ImpersonationResponse{
+		AccessToken:    accessToken,
+		TokenType:      "Bearer",
+		ExpiresIn:      int64(impersonationTTL.Seconds()),
+		User:           adminUserResponse(user),
+		ImpersonatedBy: claims.UserID,
+	}, nil
+}
+
+// Stats 사용자 통계
+func (s *AdminUserService) Stats(ctx context.Context) (*dto.UserStatsResponse, error) {
+	stats, err := s.userRepo.Stats(ctx, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[string]int64, len(stats.ByRole))
+	for role, count := range stats.ByRole {
+		byRole[string(role)] = count
+	}
+
+	return &dto.UserStatsResponse{
+		Total:       stats.Total,
+		Suspended:   stats.Suspended,
+		Unverified:  stats.Unverified,
+		NewLastWeek: stats.NewSince,
+		ByRole:      byRole,
+	}, nil
+}
+
+func (s *AdminUserService) findUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NotFoundWithID("사용자", id)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// findManageableUser는 요청한 관리자가 관리할 수 있는 사용자를 조회합니다.
+func (s *AdminUserService) findManageableUser(ctx context.Context, id uint) (*domain.User, error) {
+	if actorID(ctx) == id {
+		return nil, apperror.Forbidden("자기 자신에게는 사용할 수 없습니다")
+	}
+
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleService.CheckAuthority(ctx, user.Role); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AdminUserService) revokeAllTokens(ctx context.Context, userID uint) error {
+	if err := s.tokenService.RevokeAllSessions(ctx, userID); err != nil {
+		return err
+	}
+	return s.personalTokenRepo.DeleteAllByUser(ctx, userID)
+}
+
+// actorID는 요청한 사용자 ID입니다. (로그 기록용)
+func actorID(ctx context.Context) uint {
+	if claims, ok := middleware.GetUserFromContext(ctx); ok {
+		return claims.UserID
+	}
+	return 0
+}
+
+func adminUserResponse(user *domain.User) *dto.AdminUserResponse {
+	return &dto.AdminUserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		Username:         user.Username,
+		Role:             string(user.Role),
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
+		Suspended:        user.IsSuspended(time.Now()),
+		SuspendedAt:      user.SuspendedAt,
+		SuspendedUntil:   user.SuspendedUntil,
+		SuspendReason:    user.SuspendReason,
+		LastLoginAt:      user.LastLoginAt,
+		CreatedAt:        user.CreatedAt,
+	}
+}
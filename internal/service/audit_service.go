@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+	"log/slog"
+	"math"
+	"unicode/utf8"
+)
+
+// 저장할 User-Agent, 요청 ID 최대 길이 (컬럼 크기) - 둘 다 클라이언트가 보낸 값이라 길이를 제한
+const (
+	maxAuditUserAgentLength = 255
+	maxAuditRequestIDLength = 64
+)
+
+// AuditEntry는 기록할 감사 로그 내용입니다.
+type AuditEntry struct {
+	Action   domain.AuditAction
+	ActorID  *uint          // nil이면 요청한 사용자 (대리 로그인이면 관리자)
+	TargetID *uint          // 대상 사용자
+	Metadata map[string]any // 추가 정보 (JSON으로 저장)
+}
+
+// AuditService는 보안 감사 로그를 기록하고 조회합니다.
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Record는 감사 로그를 추가합니다. IP, User-Agent, 요청 ID는 ctx에서 가져옵니다.
+// 기록에 실패해도 요청은 계속 진행하고 에러 로그만 남깁니다. nil이면 아무것도 하지 않습니다.
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) {
+	if s == nil {
+		return
+	}
+
+	metadata := entry.Metadata
+	actorID := entry.ActorID
+	if claims, ok := middleware.GetUserFromContext(ctx); ok && actorID == nil {
+		id := claims.UserID
+		if claims.IsImpersonated() {
+			// 대리 로그인 - 실제 행위자는 관리자
+			id = claims.Actor.UserID
+			metadata = withMetadata(metadata, "impersonated_user_id", claims.UserID)
+		}
+		actorID = &id
+	}
+
+	raw := []byte("{}")
+	if len(metadata) > 0 {
+		var err error
+		if raw, err = json.Marshal(metadata); err != nil {
+			slog.ErrorContext(ctx, "audit metadata marshal failed", "action", entry.Action, "error", err)
+			raw = []byte("{}")
+		}
+	}
+
+	info := middleware.GetRequestInfoFromContext(ctx)
+
+	event := &domain.AuditEvent{
+		Action:    entry.Action,
+		ActorID:   actorID,
+		TargetID:  entry.TargetID,
+		IP:        info.IP,
+		UserAgent: truncate(info.UserAgent, maxAuditUserAgentLength),
+		RequestID: truncate(info.RequestID, maxAuditRequestIDLength),
+		Metadata:  string(raw),
+	}
+	if err := s.auditRepo.Append(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "audit record failed", "action", entry.Action, "actor_id", actorID, "target_id", entry.TargetID, "error", err)
+	}
+}
+
+// List 감사 로그 검색 (최신순)
+func (s *AuditService) List(ctx context.Context, params *dto.AuditSearchParams, pagination *dto.Pagination) ([]*dto.AuditEventResponse, *dto.Meta, error) {
+	events, total, err := s.auditRepo.Search(ctx, params, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := make([]*dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		list[i] = auditEventResponse(event)
+	}
+
+	meta := &dto.Meta{
+		Page:       pagination.Page,
+		Size:       pagination.Size,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Size))),
+	}
+	return list, meta, nil
+}
+
+// Export 검색 조건에 맞는 감사 로그를 오래된 순으로 fn에 넘깁니다. (NDJSON 내보내기)
+func (s *AuditService) Export(ctx context.Context, params *dto.AuditSearchParams, fn func(*dto.AuditEventResponse) error) error {
+	return s.auditRepo.Each(ctx, params, func(event *domain.AuditEvent) error {
+		return fn(auditEventResponse(event))
+	})
+}
+
+// Verify 해시 체인 검증
+func (s *AuditService) Verify(ctx context.Context) (*dto.AuditVerifyResponse, error) {
+	checked, broken, lastHash, err := s.auditRepo.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AuditVerifyResponse{
+		Valid:    broken == nil,
+		Checked:  checked,
+		LastHash: lastHash,
+	}
+	if broken != nil {
+		resp.BrokenAt = &broken.ID
+		slog.WarnContext(ctx, "security event", "event", "audit_chain_broken", "audit_event_id", broken.ID)
+	}
+	return resp, nil
+}
+
+// withMetadata는 metadata에 값을 추가합니다. (원본은 수정하지 않음)
+func withMetadata(metadata map[string]any, key string, value any) map[string]any {
+	merged := make(map[string]any, len(metadata)+1)
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
+
+// truncate는 s를 최대 n바이트로 자릅니다. (UTF-8 문자 중간에서 자르지 않음)
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func auditEventResponse(event *domain.AuditEvent) *dto.AuditEventResponse {
+	return &dto.AuditEventResponse{
+		ID:        event.ID,
+		Action:    string(event.Action),
+		ActorID:   event.ActorID,
+		TargetID:  event.TargetID,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		Metadata:  json.RawMessage(event.Metadata),
+		PrevHash:  event.PrevHash,
+		Hash:      event.Hash,
+		CreatedAt: event.CreatedAt,
+	}
+}
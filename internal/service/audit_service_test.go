@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditRepository는 추가된 이벤트를 메모리에 남기고, Verify는 정해둔 결과를 반환합니다.
+type memoryAuditRepository struct {
+	repository.AuditRepository
+
+	mu     sync.Mutex
+	events []*domain.AuditEvent
+	err    error
+
+	broken *domain.AuditEvent
+}
+
+func (r *memoryAuditRepository) Append(ctx context.Context, event *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memoryAuditRepository) Verify(ctx context.Context) (int64, *domain.AuditEvent, string, error) {
+	return int64(len(r.events)), r.broken, "last", nil
+}
+
+func TestAuditService_RecordActor(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	s := NewAuditService(repo)
+	targetID := uint(3)
+
+	// 비로그인 요청은 행위자 없음
+	s.Record(context.Background(), AuditEntry{Action: domain.AuditLoginFailed})
+
+	// 로그인한 사용자가 행위자
+	s.Record(as(2, domain.RoleUser), AuditEntry{Action: domain.AuditLogout})
+
+	// 대리 로그인이면 관리자가 행위자이고, 대상 사용자는 metadata에 남김
+	impersonated := middleware.SetUserToContext(context.Background(), &auth.CustomClaims{
+		UserID: 2, Role: string(domain.RoleUser), Actor: &auth.ActorClaim{Subject: "1", UserID: 1},
+	})
+	s.Record(impersonated, AuditEntry{Action: domain.AuditRoleChanged, TargetID: &targetID, Metadata: map[string]any{"role": "support"}})
+
+	require.Len(t, repo.events, 3)
+	assert.Nil(t, repo.events[0].ActorID)
+	assert.Equal(t, "{}", repo.events[0].Metadata)
+
+	require.NotNil(t, repo.events[1].ActorID)
+	assert.Equal(t, uint(2), *repo.events[1].ActorID)
+
+	require.NotNil(t, repo.events[2].ActorID)
+	assert.Equal(t, uint(1), *repo.events[2].ActorID)
+	assert.Equal(t, &targetID, repo.events[2].TargetID)
+	assert.JSONEq(t, `{"role":"support","impersonated_user_id":2}`, repo.events[2].Metadata)
+}
+
+func TestAuditService_RecordRequestInfo(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	s := NewAuditService(repo)
+
+	ctx := middleware.SetRequestInfoToContext(context.Background(), middleware.RequestInfo{
+		IP:        "10.0.0.1",
+		UserAgent: strings.Repeat("가", 100), // 300바이트
+		RequestID: strings.Repeat("r", 100),
+	})
+	s.Record(ctx, AuditEntry{Action: domain.AuditLoginSucceeded})
+
+	require.Len(t, repo.events, 1)
+	event := repo.events[0]
+	assert.Equal(t, "10.0.0.1", event.IP)
+	// 컬럼 크기에 맞춰 자르되 UTF-8 문자 중간에서 자르지 않음
+	assert.Equal(t, strings.Repeat("가", 85), event.UserAgent)
+	assert.Len(t, event.RequestID, maxAuditRequestIDLength)
+}
+
+func TestAuditService_RecordIgnoresFailures(t *testing.T) {
+	// 기록에 실패해도 요청은 계속 진행
+	s := NewAuditService(&memoryAuditRepository{err: errors.New("db down")})
+	assert.NotPanics(t, func() { s.Record(context.Background(), AuditEntry{Action: domain.AuditLogout}) })
+
+	// 감사 로그를 쓰지 않는 구성
+	var disabled *AuditService
+	assert.NotPanics(t, func() { disabled.Record(context.Background(), AuditEntry{Action: domain.AuditLogout}) })
+}
+
+func TestAuditService_Verify(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryAuditRepository{events: make([]*domain.AuditEvent, 2)}
+	s := NewAuditService(repo)
+
+	resp, err := s.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, int64(2), resp.Checked)
+	assert.Nil(t, resp.BrokenAt)
+
+	repo.broken = &domain.AuditEvent{ID: 42}
+	resp, err = s.Verify(ctx)
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	require.NotNil(t, resp.BrokenAt)
+	assert.Equal(t, uint(42), *resp.BrokenAt)
+}
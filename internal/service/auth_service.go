@@ -4,45 +4,144 @@ import (
 	"context"
 	"errors"
 	"gorm-test/internal/auth"
+	"gorm-test/internal/config"
 	"gorm-test/internal/domain"
 	"gorm-test/internal/dto"
+	"gorm-test/internal/mailer"
+	"gorm-test/internal/oidc"
 	"gorm-test/internal/repository"
+	"gorm-test/pkg/apperror"
+	"gorm-test/pkg/metrics"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 )
 
 var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrAccountSuspended   = errors.New("account suspended")
 )
 
+// SuspendedError는 이용 정지된 계정의 정지 사유와 기간입니다.
+// errors.Is(err, ErrAccountSuspended)로 확인할 수 있습니다.
+type SuspendedError struct {
+	Reason string
+	Until  *time.Time // nil이면 영구 정지
+}
+
+func (e *SuspendedError) Error() string {
+	if e.Until == nil {
+		return "account suspended permanently"
+	}
+	return "account suspended until " + e.Until.Format(time.RFC3339)
+}
+
+func (e *SuspendedError) Is(target error) bool {
+	return target == ErrAccountSuspended
+}
+
+// checkSuspended는 이용 정지된 사용자면 SuspendedError를 반환합니다.
+func checkSuspended(user *domain.User) error {
+	if !user.IsSuspended(time.Now()) {
+		return nil
+	}
+	return &SuspendedError{Reason: user.SuspendReason, Until: user.SuspendedUntil}
+}
+
 type AuthService interface {
 	Signup(ctx context.Context, req *dto.SignupRequest) (*dto.SignupResponse, error)
-	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Login(ctx context.Context, req *dto.LoginRequest, meta auth.SessionMeta) (*dto.LoginResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*dto.RefreshResponse, error)
+	Logout(ctx context.Context, userID uint, sessionID string) error
+	UnlockAccount(ctx context.Context, userID uint) error
+
+	// 이메일 인증
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+
+	// 비밀번호 재설정
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+
+	// 2단계 인증 (TOTP)
+	SetupTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorSetupResponse, error)
+	ConfirmTwoFactor(ctx context.Context, userID uint, code string) (*dto.TwoFactorConfirmResponse, error)
+	DisableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorDisableRequest) error
+	VerifyTwoFactor(ctx context.Context, req *dto.TwoFactorVerifyRequest, meta auth.SessionMeta) (*dto.LoginResponse, error)
+
+	// 외부 IdP 로그인 (OIDC)
+	LoginWithIdentity(ctx context.Context, identity *oidc.Identity, meta auth.SessionMeta) (*dto.LoginResponse, error)
+
+	// 세션(기기) 관리
+	ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) (int, error)
+
+	// Personal Access Token
+	CreatePersonalToken(ctx context.Context, userID uint, req *dto.CreatePersonalTokenRequest) (*dto.CreatePersonalTokenResponse, error)
+	ListPersonalTokens(ctx context.Context, userID uint) ([]dto.PersonalTokenResponse, error)
+	RevokePersonalToken(ctx context.Context, userID, tokenID uint) error
+	VerifyPersonalToken(ctx context.Context, token string) (*auth.CustomClaims, error)
 }
 
 type authService struct {
-	userRepo        repository.UserRepository
-	passwordService *auth.PasswordService
-	tokenService    *auth.TokenService
+	userRepo          repository.UserRepository
+	userTokenRepo     repository.UserTokenRepository
+	recoveryRepo      repository.RecoveryCodeRepository
+	identityRepo      repository.UserIdentityRepository
+	personalTokenRepo repository.PersonalAccessTokenRepository
+	loginEventRepo    repository.LoginEventRepository
+	passwordService   *auth.PasswordService
+	tokenService      *auth.TokenService
+	permissions       auth.PermissionChecker
+	loginGuard        *auth.LoginGuard
+	mailer            mailer.Mailer
+	loginNotifier     LoginNotifier
+	audit             *AuditService
+	cfg               config.AuthConfig
 }
 
 func NewAuthService(
 	userRepo repository.UserRepository,
+	userTokenRepo repository.UserTokenRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	identityRepo repository.UserIdentityRepository,
+	personalTokenRepo repository.PersonalAccessTokenRepository,
+	loginEventRepo repository.LoginEventRepository,
 	passwordService *auth.PasswordService,
 	tokenService *auth.TokenService,
+	permissions auth.PermissionChecker,
+	loginGuard *auth.LoginGuard,
+	mailer mailer.Mailer,
+	loginNotifier LoginNotifier,
+	audit *AuditService,
+	cfg config.AuthConfig,
 ) AuthService {
 	return &authService{
-		userRepo:        userRepo,
-		passwordService: passwordService,
-		tokenService:    tokenService,
+		userRepo:          userRepo,
+		userTokenRepo:     userTokenRepo,
+		recoveryRepo:      recoveryRepo,
+		identityRepo:      identityRepo,
+		personalTokenRepo: personalTokenRepo,
+		loginEventRepo:    loginEventRepo,
+		passwordService:   passwordService,
+		tokenService:      tokenService,
+		permissions:       permissions,
+		loginGuard:        loginGuard,
+		mailer:            mailer,
+		loginNotifier:     loginNotifier,
+		audit:             audit,
+		cfg:               cfg,
 	}
 }
 
 func (s *authService) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.SignupResponse, error) {
 	// 1. 비밀번호 유효성 검사
-	if err := s.passwordService.ValidatePassword(req.Password); err != nil {
+	if err := validatePassword(s.passwordService, "password", req.Password, req.Email, req.Username); err != nil {
 		return nil, err
 	}
 
@@ -61,7 +160,7 @@ func (s *authService) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.
 		return nil, err
 	}
 
-	// 4. 사용자 생성
+	// 4. 사용자 생성 (이메일 인증 전까지 읽기 전용)
 	user := &domain.User{
 		Email:    req.Email,
 		Password: hashedPassword,
@@ -76,23 +175,43 @@ func (s *authService) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.
 		return nil, err
 	}
 
-	// 5. 응답 생성
+	// 5. 인증 메일 발송 (실패해도 가입은 유지 - 재발송 가능)
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		slog.ErrorContext(ctx, "verification email failed", "user_id", user.ID, "error", err)
+	}
+
+	// 6. 응답 생성
 	return &dto.SignupResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		Role:      string(user.Role),
-		CreatedAt: user.CreatedAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		Role:          string(user.Role),
+		EmailVerified: user.IsEmailVerified(),
+		CreatedAt:     user.CreatedAt,
 	}, nil
 }
 
-func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+func (s *authService) Login(ctx context.Context, req *dto.LoginRequest, meta auth.SessionMeta) (*dto.LoginResponse, error) {
+	// 0. 잠금 확인 (계정/IP)
+	if err := s.loginGuard.Check(ctx, req.Email, meta.IP); err != nil {
+		if errors.Is(err, auth.ErrLoginLocked) {
+			metrics.UserLogins.WithLabelValues("locked").Inc()
+			slog.Warn("login blocked", "email", req.Email, "ip", meta.IP, "reason", err.Error())
+			s.audit.Record(ctx, AuditEntry{
+				Action:   domain.AuditLoginFailed,
+				Metadata: map[string]any{"email": req.Email, "reason": "locked"},
+			})
+		}
+		return nil, err
+	}
+
 	// 1. 사용자 조회
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		slog.Warn("login failed", "email", req.Email, "reasen", "user_not_found")
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return nil, ErrInvalidCredentials
+			// 없는 계정도 실패로 기록 (가입 여부 노출 방지)
+			return nil, s.loginFailed(ctx, req.Email, nil, domain.LoginMethodPassword, meta, "user_not_found", ErrInvalidCredentials)
 		}
 		return nil, err
 	}
@@ -100,42 +219,110 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 	// 2. 비밀번호 검증
 	if err := s.passwordService.Compare(user.Password, req.Password); err != nil {
 		slog.Warn("login failed", "email", req.Email, "user_id", user.ID, "reason", "invalid_password")
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, req.Email, user, domain.LoginMethodPassword, meta, "invalid_password", ErrInvalidCredentials)
 	}
 
-	// 3. 토큰 생성
-	accessToken, err := s.tokenService.GenerateAccessToken(
-		user.ID,
-		user.Email,
-		user.Username,
-		string(user.Role),
-	)
+	// 오래된 해시 알고리즘/파라미터면 새 해시로 교체 (평문을 아는 지금만 가능)
+	if s.passwordService.NeedsRehash(user.Password) {
+		s.rehashPassword(ctx, user, req.Password)
+	}
+
+	// 3. 2단계 인증 사용자는 코드 확인 대기 (토큰 미발급)
+	if user.IsTwoFactorEnabled() {
+		return s.twoFactorChallenge(user, req.DeviceName)
+	}
+
+	meta.DeviceName = req.DeviceName
+	return s.completeLogin(ctx, user, domain.LoginMethodPassword, meta)
+}
+
+// validatePassword는 비밀번호 정책을 검사하고, 위반하면 field에 대한 ValidationError를 반환합니다.
+// 이메일/사용자 이름은 비밀번호에 포함되었는지 확인하는 데 사용합니다.
+func validatePassword(passwordService *auth.PasswordService, field, password string, userInputs ...string) error {
+	err := passwordService.ValidatePassword(password, userInputs...)
+
+	var policyErr *auth.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return err
+	}
+
+	appErr := apperror.ValidationError(map[string]string{
+		field: "비밀번호는 " + strings.Join(policyErr.Messages(passwordService.Policy()), ", "),
+	})
+	appErr.Err = policyErr
+	return appErr
+}
+
+// rehashPassword는 비밀번호를 현재 알고리즘으로 다시 해싱해서 저장합니다.
+// 실패해도 로그인은 계속 진행하고 다음 로그인 때 다시 시도합니다.
+func (s *authService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	hashedPassword, err := s.passwordService.Hash(password)
+	if err != nil {
+		slog.ErrorContext(ctx, "password rehash failed", "user_id", user.ID, "error", err)
+		return
+	}
+
+	previous := user.Password
+	user.Password = hashedPassword
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		user.Password = previous
+		slog.ErrorContext(ctx, "password rehash failed", "user_id", user.ID, "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "password rehashed", "user_id", user.ID)
+}
+
+// completeLogin은 인증이 끝난 사용자의 세션을 만들고 토큰을 발급합니다.
+// method는 로그인 기록에 남길 마지막 인증 수단입니다.
+func (s *authService) completeLogin(ctx context.Context, user *domain.User, method domain.LoginMethod, meta auth.SessionMeta) (*dto.LoginResponse, error) {
+	// 0. 이용 정지 확인 (비밀번호/외부 로그인/2단계 인증 모두 여기를 거침)
+	if err := checkSuspended(user); err != nil {
+		metrics.UserLogins.WithLabelValues("suspended").Inc()
+		s.recordLogin(ctx, user, method, meta, "suspended")
+		return nil, err
+	}
+
+	// 1. 세션 생성 (기기별 Refresh Token)
+	refreshToken, session, err := s.tokenService.CreateSession(ctx, user.ID, meta)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.tokenService.GenerateRefreshToken(user.ID)
+	// 2. 토큰 생성
+	accessToken, err := s.tokenService.GenerateAccessToken(tokenSubject(user), session)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. 마지막 로그인 시간 업데이트
+	// 3. 마지막 로그인 시간 업데이트 + 실패 횟수 초기화
 	now := time.Now()
 	user.LastLoginAt = &now
 	_ = s.userRepo.Update(ctx, user) // 에러는 무시 (로그인 성공에 영향 없음)
-	slog.Info("login success", "user_id", user.ID, "email", user.Email)
+	if err := s.loginGuard.RecordSuccess(ctx, user.Email); err != nil {
+		slog.ErrorContext(ctx, "login guard reset failed", "user_id", user.ID, "error", err)
+	}
+	metrics.UserLogins.WithLabelValues("success").Inc()
+	slog.Info("login success", "user_id", user.ID, "email", user.Email, "session_id", session.ID, "two_factor", session.TwoFactor)
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditLoginSucceeded,
+		ActorID:  &user.ID,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"session_id": session.ID, "device_name": meta.DeviceName, "two_factor": session.TwoFactor},
+	})
+	s.recordLogin(ctx, user, method, meta, "")
 
-	// 5. 응답 생성
+	// 4. 응답 생성
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		TokenType:    "Bearer",
 		ExpiresIn:    s.tokenService.GetAccessExpiry(),
-		User: dto.UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Username: user.Username,
-			Role:     string(user.Role),
+		User: &dto.UserResponse{
+			ID:            user.ID,
+			Email:         user.Email,
+			Username:      user.Username,
+			Role:          string(user.Role),
+			EmailVerified: user.IsEmailVerified(),
 		},
 	}, nil
 }
@@ -143,19 +330,21 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 /*
 Token Rotation :: Access & Refresh Token 모두 재발급
 
-Refresh Token 탈취 감지: 탈취된 토큰과 정상 토큰이 동시에 사용되면 감지 가능
+Refresh Token 탈취 감지: 이미 교체된 토큰이 다시 제출되면 세션(토큰 패밀리) 전체를 폐기하고
+ErrRefreshTokenReused를 반환 - 클라이언트는 다시 로그인해야 함
 만료 시간 연장: 활성 사용자는 계속 새 토큰을 받아 세션 유지
 보안 강화: 오래된 Refresh Token이 자동으로 무효화
 */
 func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*dto.RefreshResponse, error) {
-	// 1. Refresh Token 검증
-	userID, err := s.tokenService.ValidateRefreshToken(refreshToken)
+	// 1. Refresh Token 검증 (세션 확인 포함)
+	session, err := s.tokenService.ValidateRefreshToken(ctx, refreshToken)
 	if err != nil {
+		s.reportTokenReuse(ctx, err)
 		return nil, err
 	}
 
 	// 2. 사용자 조회 (존재 여부 확인)
-	user, err := s.userRepo.FindByID(ctx, userID)
+	user, err := s.userRepo.FindByID(ctx, session.UserID)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return nil, ErrInvalidCredentials
@@ -163,22 +352,29 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, err
 	}
 
+	if err := checkSuspended(user); err != nil {
+		return nil, err
+	}
+
 	// 3. 새 Access Token 생성
-	newAccessToken, err := s.tokenService.GenerateAccessToken(
-		user.ID,
-		user.Email,
-		user.Username,
-		string(user.Role),
-	)
+	newAccessToken, err := s.tokenService.GenerateAccessToken(tokenSubject(user), session)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. 새 Refresh Token 생성 (Token Rotation)
-	newRefreshToken, err := s.tokenService.GenerateRefreshToken(user.ID)
+	// 4. 새 Refresh Token 생성 (Token Rotation - 같은 세션 유지)
+	// 같은 토큰으로 동시에 갱신하면 한 요청만 성공 (나머지는 재사용으로 처리)
+	newRefreshToken, err := s.tokenService.RotateRefreshToken(ctx, session)
 	if err != nil {
+		s.reportTokenReuse(ctx, err)
 		return nil, err
 	}
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditTokenRefreshed,
+		ActorID:  &user.ID,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"session_id": session.ID},
+	})
 
 	return &dto.RefreshResponse{
 		AccessToken:  newAccessToken,
@@ -187,3 +383,149 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		ExpiresIn:    s.tokenService.GetAccessExpiry(),
 	}, nil
 }
+
+// reportTokenReuse는 교체된 Refresh Token 재사용을 보안 이벤트로 기록합니다.
+// 토큰 패밀리(세션)는 TokenService에서 이미 폐기되었습니다.
+func (s *authService) reportTokenReuse(ctx context.Context, err error) {
+	var reuseErr *auth.TokenReuseError
+	if !errors.As(err, &reuseErr) {
+		return
+	}
+
+	metrics.SecurityEvents.WithLabelValues("refresh_token_reuse").Inc()
+	slog.WarnContext(ctx, "security event",
+		"event", "refresh_token_reuse",
+		"user_id", reuseErr.UserID,
+		"session_id", reuseErr.SessionID,
+	)
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditTokenReused,
+		TargetID: &reuseErr.UserID,
+		Metadata: map[string]any{"session_id": reuseErr.SessionID},
+	})
+}
+
+// loginFailed는 로그인 실패를 기록합니다. user는 없는 계정이면 nil입니다.
+// 이번 실패로 잠기면 잠금 에러를, 아니면 cause를 반환합니다.
+func (s *authService) loginFailed(ctx context.Context, email string, user *domain.User, method domain.LoginMethod, meta auth.SessionMeta, reason string, cause error) error {
+	metrics.UserLogins.WithLabelValues("failure").Inc()
+
+	var userID *uint
+	if user != nil {
+		userID = &user.ID
+		s.recordLogin(ctx, user, method, meta, reason)
+	}
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditLoginFailed,
+		TargetID: userID,
+		Metadata: map[string]any{"email": email, "reason": reason},
+	})
+
+	if err := s.loginGuard.RecordFailure(ctx, email, meta.IP); err != nil {
+		if errors.Is(err, auth.ErrLoginLocked) {
+			slog.Warn("login locked", "email", email, "ip", meta.IP, "reason", err.Error())
+		}
+		return err
+	}
+	return cause
+}
+
+// UnlockAccount는 로그인 실패로 잠긴 계정을 해제합니다. (관리자)
+func (s *authService) UnlockAccount(ctx context.Context, userID uint) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if err := s.loginGuard.Unlock(ctx, user.Email); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "account unlocked", "user_id", user.ID)
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditUserUnlocked, TargetID: &user.ID})
+	return nil
+}
+
+// tokenSubject는 Access Token에 담을 사용자 정보를 만듭니다.
+func tokenSubject(user *domain.User) auth.TokenSubject {
+	return auth.TokenSubject{
+		UserID:        user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		Role:          string(user.Role),
+		EmailVerified: user.IsEmailVerified(),
+	}
+}
+
+// Logout은 현재 세션을 종료합니다. 다른 기기의 세션은 유지됩니다.
+func (s *authService) Logout(ctx context.Context, userID uint, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	err := s.tokenService.RevokeSession(ctx, userID, sessionID)
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		// 이미 만료/종료된 세션
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditLogout,
+		TargetID: &userID,
+		Metadata: map[string]any{"session_id": sessionID},
+	})
+	return nil
+}
+
+// ListSessions는 사용자의 활성 세션 목록을 최근 사용 순으로 반환합니다.
+func (s *authService) ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := s.tokenService.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	responses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = dto.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.ID == currentSessionID,
+		}
+	}
+	return responses, nil
+}
+
+// RevokeSession은 사용자의 세션 하나를 종료합니다.
+func (s *authService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	if err := s.tokenService.RevokeSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	slog.InfoContext(ctx, "session revoked", "user_id", userID, "session_id", sessionID)
+	return nil
+}
+
+// RevokeOtherSessions는 현재 세션을 제외한 모든 세션을 종료합니다. (다른 기기 모두 로그아웃)
+func (s *authService) RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) (int, error) {
+	revoked, err := s.tokenService.RevokeOtherSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		return revoked, err
+	}
+	slog.InfoContext(ctx, "other sessions revoked", "user_id", userID, "revoked", revoked)
+	return revoked, nil
+}
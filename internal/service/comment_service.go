@@ -1,43 +1,69 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"gorm-test/internal/auth"
 	"gorm-test/internal/domain"
 	"gorm-test/internal/dto"
+	"gorm-test/internal/filter"
 	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+	"gorm-test/pkg/metrics"
+	"math"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 var (
-	ErrCommentNotFound = errors.New("댓글을 찾을 수 없습니다")
-	ErrPostNotExists   = errors.New("게시글이 존재하지 않습니다")
+	ErrCommentNotFound    = errors.New("댓글을 찾을 수 없습니다")
+	ErrPostNotExists      = errors.New("게시글이 존재하지 않습니다")
+	ErrInvalidCommentMove = errors.New("댓글을 자기 자신 또는 하위 댓글 아래로 옮길 수 없습니다")
+	ErrCommentTooDeep     = errors.New("최대 대댓글 깊이를 초과합니다")
 )
 
 const MaxReplyDepth = 3 // 최대 3단계까지
 
 type CommentService struct {
-	commentRepo repository.CommentRepository
-	postRepo    repository.PostRepository
+	commentRepo   repository.CommentRepository
+	postRepo      repository.PostRepository
+	contentFilter *filter.Pipeline
+	permissions   auth.PermissionChecker
 }
 
-func NewCommentService(commentRepo repository.CommentRepository, postRepo repository.PostRepository) *CommentService {
+func NewCommentService(commentRepo repository.CommentRepository, postRepo repository.PostRepository, contentFilter *filter.Pipeline, permissions auth.PermissionChecker) *CommentService {
 	return &CommentService{
-		commentRepo: commentRepo,
-		postRepo:    postRepo,
+		commentRepo:   commentRepo,
+		postRepo:      postRepo,
+		contentFilter: contentFilter,
+		permissions:   permissions,
 	}
 }
 
 // Create 댓글 생성
-func (s *CommentService) Create(postID uint, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
-	// 게시글 존재 확인
-	_, err := s.postRepo.FindByID(postID)
+func (s *CommentService) Create(ctx context.Context, postID uint, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	// 게시글 존재 확인 - 검토 대기 중인 게시글은 공개 전이므로 댓글 불가
+	post, err := s.postRepo.FindByID(postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPostNotExists
 		}
 		return nil, err
 	}
+	if post.Status != domain.ModerationApproved {
+		return nil, ErrPostNotExists
+	}
+
+	// 댓글 잠금 확인 (기존 댓글 조회는 가능)
+	if post.IsCommentsLocked(time.Now()) {
+		appErr := apperror.Locked("댓글 작성이 잠긴 게시글입니다")
+		if post.CommentsUnlockAt != nil {
+			appErr.WithDetail("잠금 해제 예정: " + post.CommentsUnlockAt.Format(time.RFC3339))
+		}
+		return nil, appErr
+	}
 
 	// 부모 댓글 확인 (대댓글인 경우)
 	if req.ParentID != nil {
@@ -51,37 +77,65 @@ func (s *CommentService) Create(postID uint, req *dto.CreateCommentRequest) (*dt
 		}
 
 		// 깊이 확인
-		depth := s.getCommentDepth(parent)
-		if depth >= MaxReplyDepth {
+		if parent.Depth() >= MaxReplyDepth {
 			return nil, errors.New("더 이상 대댓글을 작성할 수 없습니다")
 		}
 
 	}
 
+	// 스팸/어뷰징 필터
+	content := &filter.Content{Target: filter.TargetComment, Text: req.Content}
+	var authorID *uint
+	if claims, ok := middleware.GetUserFromContext(ctx); ok {
+		content.UserID = claims.UserID
+		authorID = &claims.UserID
+	}
+	result, err := s.contentFilter.Run(ctx, content)
+	if err != nil {
+		return nil, apperror.InternalError(err).WithDetail("콘텐츠 필터 실행 실패")
+	}
+	if result.Decision == filter.DecisionReject {
+		return nil, filter.RejectError(result)
+	}
+
+	status := domain.ModerationApproved
+	if result.Decision == filter.DecisionHold {
+		status = domain.ModerationPending
+	}
+
 	comment := &domain.Comment{
 		PostID:   postID,
 		ParentID: req.ParentID,
 		Content:  req.Content,
 		Author:   req.Author,
+		AuthorID: authorID,
+		Status:   status,
 	}
 
 	if err := s.commentRepo.Create(comment); err != nil {
+		result.Release()
 		return nil, err
 	}
 
+	if comment.Status == domain.ModerationApproved {
+		metrics.CommentsTotal.Inc()
+	}
 	return s.toResponse(comment), nil
 }
 
 // GetByPostID 게시글의 댓글 목록 조회
 func (s *CommentService) GetByPostID(postID uint) ([]*dto.CommentResponse, error) {
-	// 게시글 존재 확인
-	_, err := s.postRepo.FindByID(postID)
+	// 게시글 존재 확인 (검토 대기 중인 게시글은 공개 전)
+	post, err := s.postRepo.FindByID(postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPostNotExists
 		}
 		return nil, err
 	}
+	if post.Status != domain.ModerationApproved {
+		return nil, ErrPostNotExists
+	}
 
 	comments, err := s.commentRepo.FindByPostIDWithReplies(postID)
 	if err != nil {
@@ -96,8 +150,8 @@ func (s *CommentService) GetByPostID(postID uint) ([]*dto.CommentResponse, error
 	return result, nil
 }
 
-// Update 댓글 수정
-func (s *CommentService) Update(id uint, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+// Update 댓글 수정 - 작성자 본인 또는 comment.edit.any 권한이 있는 역할만 가능
+func (s *CommentService) Update(ctx context.Context, id uint, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
 	comment, err := s.commentRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -106,6 +160,10 @@ func (s *CommentService) Update(id uint, req *dto.UpdateCommentRequest) (*dto.Co
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, comment, domain.PermCommentEditAny); err != nil {
+		return nil, err
+	}
+
 	comment.Content = req.Content
 
 	if err := s.commentRepo.Update(comment); err != nil {
@@ -115,8 +173,8 @@ func (s *CommentService) Update(id uint, req *dto.UpdateCommentRequest) (*dto.Co
 	return s.toResponse(comment), nil
 }
 
-// Delete 댓글 삭제 (내용만 삭제)
-func (s *CommentService) Delete(id uint) error {
+// Delete 댓글 삭제 (대댓글이 있으면 내용만 삭제) - 작성자 본인 또는 comment.delete.any 권한이 있는 역할만 가능
+func (s *CommentService) Delete(ctx context.Context, id uint) error {
 	comment, err := s.commentRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -125,6 +183,10 @@ func (s *CommentService) Delete(id uint) error {
 		return err
 	}
 
+	if err := s.authorize(ctx, comment, domain.PermCommentDeleteAny); err != nil {
+		return err
+	}
+
 	// 대댓글이 있으면 내용만 변경
 	hasReplies, _ := s.commentRepo.HasReplies(id)
 	if hasReplies {
@@ -133,8 +195,156 @@ func (s *CommentService) Delete(id uint) error {
 		return s.commentRepo.Update(comment)
 	}
 
-	return s.commentRepo.Delete(id)
+	if err := s.commentRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if comment.Status == domain.ModerationApproved {
+		metrics.CommentsTotal.Dec()
+	}
+	return nil
 }
+
+// authorize는 작성자 본인이 아니면 permission을 확인합니다.
+// 작성자 정보가 없는 댓글(비로그인/이전 댓글)은 permission이 있어야 합니다.
+func (s *CommentService) authorize(ctx context.Context, comment *domain.Comment, permission domain.Permission) error {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+	if comment.AuthorID != nil && *comment.AuthorID == claims.UserID {
+		return nil
+	}
+	return requirePermission(ctx, s.permissions, claims, permission)
+}
+
+// Restore 삭제된 댓글 복구
+func (s *CommentService) Restore(id uint) (*dto.CommentResponse, error) {
+	comment, err := s.commentRepo.Restore(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	if comment.Status == domain.ModerationApproved {
+		metrics.CommentsTotal.Inc()
+	}
+	return s.toResponse(comment), nil
+}
+
+// ListPending 검토 대기 댓글 목록 (관리자)
+func (s *CommentService) ListPending(pagination *dto.Pagination) ([]*dto.CommentResponse, *dto.Meta, error) {
+	comments, total, err := s.commentRepo.FindPending(pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := make([]*dto.CommentResponse, len(comments))
+	for i := range comments {
+		list[i] = s.toResponse(&comments[i])
+	}
+
+	meta := &dto.Meta{
+		Page:       pagination.Page,
+		Size:       pagination.Size,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Size))),
+	}
+	return list, meta, nil
+}
+
+// Approve 검토 대기 댓글 승인 (공개, 관리자)
+func (s *CommentService) Approve(id uint) (*dto.CommentResponse, error) {
+	comment, err := s.commentRepo.Approve(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	metrics.CommentsTotal.Inc()
+	return s.toResponse(comment), nil
+}
+
+// Reject 검토 대기 댓글 거부 (삭제, 관리자)
+func (s *CommentService) Reject(id uint) error {
+	if err := s.commentRepo.Reject(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Move 댓글(하위 댓글 포함)을 다른 부모 또는 다른 게시글로 이동 (관리자)
+func (s *CommentService) Move(id uint, req *dto.MoveCommentRequest) (*dto.CommentResponse, error) {
+	var postID uint
+	if req.ParentID == nil {
+		// 최상위로 이동하는 경우 대상 게시글 필요
+		if req.PostID == nil {
+			return nil, apperror.BadRequest("parent_id 또는 post_id가 필요합니다")
+		}
+		if _, err := s.postRepo.FindByID(*req.PostID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrPostNotExists
+			}
+			return nil, err
+		}
+		postID = *req.PostID
+	}
+
+	comment, err := s.commentRepo.MoveSubtree(id, req.ParentID, postID, MaxReplyDepth)
+	if err != nil {
+		return nil, s.translateTreeError(err)
+	}
+
+	return s.toResponse(comment), nil
+}
+
+// Merge source 댓글의 대댓글을 target 댓글 아래로 합치고 source 댓글은 삭제 (관리자)
+func (s *CommentService) Merge(sourceID, targetID uint) error {
+	if sourceID == targetID {
+		return ErrInvalidCommentMove
+	}
+
+	removed, err := s.commentRepo.MergeInto(sourceID, targetID, MaxReplyDepth)
+	if err != nil {
+		return s.translateTreeError(err)
+	}
+
+	metrics.CommentsTotal.Sub(float64(removed))
+	return nil
+}
+
+// DeleteTree 댓글과 모든 하위 댓글 삭제 (관리자)
+func (s *CommentService) DeleteTree(id uint) error {
+	removed, err := s.commentRepo.DeleteSubtree(id)
+	if err != nil {
+		return s.translateTreeError(err)
+	}
+
+	metrics.CommentsTotal.Sub(float64(removed))
+	return nil
+}
+
+// translateTreeError 저장소 트리 연산 에러를 서비스 에러로 변환
+func (s *CommentService) translateTreeError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrCommentNotFound
+	case errors.Is(err, repository.ErrCommentCycle):
+		return ErrInvalidCommentMove
+	case errors.Is(err, repository.ErrCommentTooDeep):
+		return ErrCommentTooDeep
+	default:
+		return err
+	}
+}
+
 func (s *CommentService) toResponse(comment *domain.Comment) *dto.CommentResponse {
 	return &dto.CommentResponse{
 		ID:        comment.ID,
@@ -142,27 +352,11 @@ func (s *CommentService) toResponse(comment *domain.Comment) *dto.CommentRespons
 		ParentID:  comment.ParentID,
 		Content:   comment.Content,
 		Author:    comment.Author,
+		Status:    string(comment.Status),
 		CreatedAt: comment.CreatedAt,
 	}
 }
 
-// getCommentDepth 댓글 깊이 계산
-func (s *CommentService) getCommentDepth(comment *domain.Comment) int {
-	depth := 1
-	current := comment
-
-	for current.ParentID != nil {
-		parent, err := s.commentRepo.FindByID(*current.ParentID)
-		if err != nil {
-			break
-		}
-		current = parent
-		depth++
-	}
-
-	return depth
-}
-
 // toResponseWithReplies 대댓글 포함 변환
 func (s *CommentService) toResponseWithReplies(comment *domain.Comment) *dto.CommentResponse {
 	resp := &dto.CommentResponse{
@@ -171,6 +365,7 @@ func (s *CommentService) toResponseWithReplies(comment *domain.Comment) *dto.Com
 		ParentID:  comment.ParentID,
 		Content:   comment.Content,
 		Author:    comment.Author,
+		Status:    string(comment.Status),
 		CreatedAt: comment.CreatedAt,
 	}
 
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/mailer"
+	"gorm-test/internal/repository"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+)
+
+// 인증 토큰 기본 유효 시간
+const defaultEmailVerificationTTL = 24 * time.Hour
+
+// VerifyEmail은 메일로 받은 토큰으로 이메일 인증을 완료합니다.
+// 토큰은 한 번만 사용할 수 있습니다.
+func (s *authService) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := s.userTokenRepo.Consume(ctx, domain.TokenPurposeEmailVerification, hashUserToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenInvalid) {
+			return ErrInvalidVerificationToken
+		}
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "email verified", "user_id", user.ID)
+	return nil
+}
+
+// ResendVerification은 인증 메일을 다시 보냅니다.
+// 가입 여부를 노출하지 않도록 없는 이메일이나 이미 인증된 계정도 에러 없이 반환합니다.
+func (s *authService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	// 이전에 보낸 토큰은 무효화
+	if err := s.userTokenRepo.InvalidateAll(ctx, user.ID, domain.TokenPurposeEmailVerification); err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail은 인증 토큰을 만들고 메일로 보냅니다.
+func (s *authService) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	ttl := s.cfg.EmailVerificationTTL
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTTL
+	}
+
+	token, err := s.issueUserToken(ctx, user.ID, domain.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(s.cfg.AppBaseURL, "/"), url.QueryEscape(token))
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "이메일 주소를 인증해주세요",
+		Body: fmt.Sprintf(
+			"%s님, 가입을 환영합니다.\n\n아래 링크를 눌러 이메일 인증을 완료해주세요.\n%s\n\n인증 코드: %s\n\n이 링크는 %s 동안 유효합니다.\n",
+			user.Username, link, token, ttl,
+		),
+	})
+}
+
+// issueUserToken은 일회용 토큰을 발급합니다. 원문을 반환하고 DB에는 해시만 저장합니다.
+func (s *authService) issueUserToken(ctx context.Context, userID uint, purpose domain.TokenPurpose, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+
+	if err := s.userTokenRepo.Create(ctx, &domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashUserToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func hashUserToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
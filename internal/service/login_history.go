@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/mailer"
+	"log/slog"
+	"time"
+)
+
+// LoginNotifier는 처음 보는 기기/위치에서 로그인했을 때 사용자에게 알립니다.
+//
+//	메일: MailLoginNotifier
+//	푸시, 메신저 등은 이 인터페이스를 구현해서 NewAuthService에 넘깁니다. (nil이면 알리지 않음)
+type LoginNotifier interface {
+	NotifyNewDevice(ctx context.Context, user *domain.User, event *domain.LoginEvent) error
+}
+
+// MailLoginNotifier는 새 기기 로그인을 메일로 알립니다.
+type MailLoginNotifier struct {
+	mailer mailer.Mailer
+}
+
+func NewMailLoginNotifier(m mailer.Mailer) *MailLoginNotifier {
+	return &MailLoginNotifier{mailer: m}
+}
+
+func (n *MailLoginNotifier) NotifyNewDevice(ctx context.Context, user *domain.User, event *domain.LoginEvent) error {
+	device := event.DeviceName
+	if device == "" {
+		device = event.UserAgent
+	}
+
+	return n.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "새 기기에서 로그인했습니다",
+		Body: fmt.Sprintf(
+			"%s님, 처음 보는 기기 또는 위치에서 계정에 로그인했습니다.\n\n시간: %s\nIP: %s\n기기: %s\n로그인 수단: %s\n\n본인이 아니라면 즉시 비밀번호를 변경하고 다른 기기에서 로그아웃하세요.\n",
+			user.Username, event.CreatedAt.Format(time.RFC3339), event.IP, device, event.Method,
+		),
+	})
+}
+
+// recordLogin은 로그인 기록을 남깁니다. failureReason이 비어 있으면 성공입니다.
+// 성공한 로그인이 처음 보는 기기/위치면 알림을 보냅니다. 기록/알림에 실패해도 로그인에는 영향이 없습니다.
+func (s *authService) recordLogin(ctx context.Context, user *domain.User, method domain.LoginMethod, meta auth.SessionMeta, failureReason string) {
+	event := &domain.LoginEvent{
+		UserID:        user.ID,
+		Method:        method,
+		Success:       failureReason == "",
+		FailureReason: failureReason,
+		IP:            meta.IP,
+		IPRange:       domain.IPRange(meta.IP),
+		UserAgent:     truncate(meta.UserAgent, maxAuditUserAgentLength),
+		DeviceName:    truncate(meta.DeviceName, 100),
+	}
+
+	if event.Success {
+		history, err := s.loginEventRepo.CheckDevice(ctx, user.ID, event.UserAgent, event.IPRange)
+		if err != nil {
+			slog.ErrorContext(ctx, "login history check failed", "user_id", user.ID, "error", err)
+		} else {
+			// 첫 로그인은 비교할 기록이 없으므로 알리지 않음
+			event.NewDevice = history.HasHistory && (!history.SeenAgent || !history.SeenRange)
+		}
+	}
+
+	if err := s.loginEventRepo.Create(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "login history record failed", "user_id", user.ID, "error", err)
+		return
+	}
+
+	if event.NewDevice && s.loginNotifier != nil {
+		slog.InfoContext(ctx, "new device login", "user_id", user.ID, "ip", event.IP, "login_event_id", event.ID)
+		// 메일 발송이 로그인 응답을 늦추지 않도록 요청과 분리해서 발송
+		go func(ctx context.Context, user domain.User) {
+			if err := s.loginNotifier.NotifyNewDevice(ctx, &user, event); err != nil {
+				slog.ErrorContext(ctx, "new device notification failed", "user_id", user.ID, "error", err)
+			}
+		}(context.WithoutCancel(ctx), *user)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"gorm-test/internal/repository"
+	"gorm-test/pkg/metrics"
+)
+
+// SeedBoardMetrics는 서버 시작 시 게시글/댓글 수 게이지를 DB 값으로 초기화합니다.
+// (게이지는 프로세스 메모리에 있으므로 재시작하면 0부터 다시 시작함)
+func SeedBoardMetrics(postRepo repository.PostRepository, commentRepo repository.CommentRepository) error {
+	posts, err := postRepo.CountVisible()
+	if err != nil {
+		return err
+	}
+
+	comments, err := commentRepo.CountVisible()
+	if err != nil {
+		return err
+	}
+
+	metrics.PostsTotal.Set(float64(posts))
+	metrics.CommentsTotal.Set(float64(comments))
+	return nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/oidc"
+	"gorm-test/internal/repository"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrExternalEmailNotVerified = errors.New("identity provider did not return a verified email")
+)
+
+// LoginWithIdentity는 IdP에서 검증된 사용자로 로그인합니다.
+//
+//  1. 이미 연결된 외부 계정이면 연결된 사용자로 로그인
+//  2. 처음이면 IdP가 인증한 이메일로 기존 사용자와 연결 (없으면 새 사용자 생성)
+//     이메일 인증이 안 된 외부 계정은 다른 사람의 계정을 가로챌 수 있으므로 거부합니다.
+func (s *authService) LoginWithIdentity(ctx context.Context, identity *oidc.Identity, meta auth.SessionMeta) (*dto.LoginResponse, error) {
+	user, err := s.findOrLinkUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2단계 인증 사용자는 외부 로그인 후에도 코드 확인
+	if user.IsTwoFactorEnabled() {
+		return s.twoFactorChallenge(user, meta.DeviceName)
+	}
+
+	return s.completeLogin(ctx, user, domain.OIDCLoginMethod(identity.Provider), meta)
+}
+
+func (s *authService) findOrLinkUser(ctx context.Context, identity *oidc.Identity) (*domain.User, error) {
+	// 1. 연결된 외부 계정
+	linked, err := s.identityRepo.FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(ctx, linked.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				return nil, ErrInvalidCredentials
+			}
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// 2. 처음 로그인 - 인증된 이메일 필요
+	if identity.Email == "" || !identity.EmailVerified {
+		slog.WarnContext(ctx, "oidc login rejected", "provider", identity.Provider, "reason", "email_not_verified")
+		return nil, ErrExternalEmailNotVerified
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// IdP가 이메일 소유를 확인했으므로 로컬 인증도 완료 처리
+		if !user.IsEmailVerified() {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+
+	case errors.Is(err, repository.ErrUserNotFound):
+		user, err = s.createExternalUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, &domain.UserIdentity{
+		UserID:   user.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "external identity linked", "user_id", user.ID, "provider", identity.Provider)
+	return user, nil
+}
+
+// createExternalUser는 외부 로그인으로 처음 들어온 사용자를 생성합니다.
+// 비밀번호는 알 수 없는 랜덤 값으로 설정합니다. (필요하면 비밀번호 재설정으로 지정)
+func (s *authService) createExternalUser(ctx context.Context, identity *oidc.Identity) (*domain.User, error) {
+	randomPassword, err := oidc.RandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := s.passwordService.Hash(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		Email:           identity.Email,
+		Password:        hashedPassword,
+		Username:        externalUsername(identity),
+		Role:            domain.RoleUser,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// externalUsername은 IdP 정보로 사용자 이름을 정합니다. (preferred_username > name > 이메일 앞부분)
+func externalUsername(identity *oidc.Identity) string {
+	name := strings.TrimSpace(identity.PreferredUsername)
+	if name == "" {
+		name = strings.TrimSpace(identity.Name)
+	}
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	// SignupRequest와 같은 길이 제한 (2~50자)
+	if utf8.RuneCountInString(name) > 50 {
+		name = string([]rune(name)[:50])
+	}
+	for utf8.RuneCountInString(name) < 2 {
+		name += "_"
+	}
+	return name
+}
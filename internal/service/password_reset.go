@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/mailer"
+	"gorm-test/internal/repository"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+// 비밀번호 재설정 기본값
+const (
+	defaultPasswordResetTTL     = 30 * time.Minute
+	defaultPasswordResetPerHour = 3
+)
+
+// ForgotPassword는 비밀번호 재설정 메일을 보냅니다.
+//
+//	가입 여부를 노출하지 않도록 없는 이메일이나 발송 한도 초과도 에러 없이 반환합니다.
+//	응답 시간으로도 드러나지 않도록 토큰 발급과 메일 발송은 요청과 분리해서 처리합니다.
+//	IP 기준 제한은 라우터의 password_reset 정책(rate_limit.policies), 계정 기준 제한은 여기서 처리합니다.
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	go s.sendPasswordReset(context.WithoutCancel(ctx), *user)
+	return nil
+}
+
+// sendPasswordReset은 재설정 토큰을 발급하고 메일을 보냅니다.
+// 요청이 끝난 뒤 실행되므로 실패는 로그로만 남깁니다.
+func (s *authService) sendPasswordReset(ctx context.Context, user domain.User) {
+	// 계정당 발송 한도
+	limit := s.cfg.PasswordResetPerHour
+	if limit <= 0 {
+		limit = defaultPasswordResetPerHour
+	}
+	sent, err := s.userTokenRepo.CountSince(ctx, user.ID, domain.TokenPurposePasswordReset, time.Now().Add(-time.Hour))
+	if err != nil {
+		slog.ErrorContext(ctx, "password reset failed", "user_id", user.ID, "error", err)
+		return
+	}
+	if sent >= int64(limit) {
+		slog.WarnContext(ctx, "password reset rate limited", "user_id", user.ID)
+		return
+	}
+
+	ttl := s.cfg.PasswordResetTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+
+	// 이전에 보낸 토큰은 무효화
+	if err := s.userTokenRepo.InvalidateAll(ctx, user.ID, domain.TokenPurposePasswordReset); err != nil {
+		slog.ErrorContext(ctx, "password reset failed", "user_id", user.ID, "error", err)
+		return
+	}
+
+	token, err := s.issueUserToken(ctx, user.ID, domain.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		slog.ErrorContext(ctx, "password reset failed", "user_id", user.ID, "error", err)
+		return
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(s.cfg.AppBaseURL, "/"), url.QueryEscape(token))
+
+	if err := s.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "비밀번호 재설정 안내",
+		Body: fmt.Sprintf(
+			"%s님, 비밀번호 재설정이 요청되었습니다.\n\n아래 링크에서 새 비밀번호를 설정해주세요.\n%s\n\n이 링크는 %s 동안 한 번만 사용할 수 있습니다.\n본인이 요청하지 않았다면 이 메일을 무시하세요.\n",
+			user.Username, link, ttl,
+		),
+	}); err != nil {
+		slog.ErrorContext(ctx, "password reset email failed", "user_id", user.ID, "error", err)
+	}
+}
+
+// ResetPassword는 재설정 토큰으로 비밀번호를 변경합니다.
+// 성공하면 사용자의 모든 세션(Refresh Token)을 폐기합니다.
+func (s *authService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	// 1. 토큰 확인 (사용 처리 전 - 비밀번호가 정책에 맞지 않아도 토큰 재사용 가능)
+	tokenHash := hashUserToken(token)
+	userToken, err := s.userTokenRepo.Find(ctx, domain.TokenPurposePasswordReset, tokenHash)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenInvalid) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// 2. 비밀번호 유효성 검사 후 토큰 사용 처리
+	if err := validatePassword(s.passwordService, "new_password", newPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
+	if _, err := s.userTokenRepo.Consume(ctx, domain.TokenPurposePasswordReset, tokenHash); err != nil {
+		if errors.Is(err, repository.ErrUserTokenInvalid) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// 3. 비밀번호 변경
+	hashedPassword, err := s.passwordService.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	// 메일을 받았다는 것은 이메일 소유가 확인된 것
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	// 4. 모든 세션 폐기 (다른 기기의 Refresh Token과 발급된 Access Token 포함)
+	if err := s.tokenService.RevokeAllSessions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "password reset", "user_id", user.ID)
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditPasswordReset, ActorID: &user.ID, TargetID: &user.ID})
+	return nil
+}
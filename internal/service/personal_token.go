@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPersonalTokenNotFound = errors.New("personal access token not found")
+	ErrScopeNotAllowed       = errors.New("scope not allowed for this user")
+)
+
+// 사용 시각 기록 간격 (요청마다 UPDATE 하지 않도록)
+const personalTokenTouchInterval = time.Minute
+
+// 토큰 목록에서 보여줄 앞부분 길이 (접두사 포함)
+const personalTokenPrefixLen = 10
+
+// CreatePersonalToken은 Personal Access Token을 발급합니다.
+func (s *authService) CreatePersonalToken(ctx context.Context, userID uint, req *dto.CreatePersonalTokenRequest) (*dto.CreatePersonalTokenResponse, error) {
+	if err := auth.ValidateScopes(req.Scopes); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// 가진 적 없는 권한은 토큰에도 줄 수 없음 (관리자 API 권한이 하나라도 있는 역할만 admin scope 허용)
+	if slices.Contains(req.Scopes, auth.ScopeAdmin) {
+		allowed, err := hasAdminPermission(ctx, s.permissions, string(user.Role))
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, ErrScopeNotAllowed
+		}
+	}
+
+	raw, err := auth.GeneratePersonalToken()
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	token := &domain.PersonalAccessToken{
+		UserID:      user.ID,
+		Name:        req.Name,
+		TokenHash:   auth.HashPersonalToken(raw),
+		TokenPrefix: raw[:personalTokenPrefixLen],
+		Scopes:      strings.Join(scopes, " "),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.personalTokenRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatePersonalTokenResponse{
+		PersonalTokenResponse: personalTokenResponse(token),
+		Token:                 raw,
+	}, nil
+}
+
+// ListPersonalTokens는 사용자의 Personal Access Token 목록을 반환합니다.
+func (s *authService) ListPersonalTokens(ctx context.Context, userID uint) ([]dto.PersonalTokenResponse, error) {
+	tokens, err := s.personalTokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.PersonalTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		resp = append(resp, personalTokenResponse(&tokens[i]))
+	}
+	return resp, nil
+}
+
+// RevokePersonalToken은 Personal Access Token을 폐기합니다.
+func (s *authService) RevokePersonalToken(ctx context.Context, userID, tokenID uint) error {
+	if err := s.personalTokenRepo.Delete(ctx, userID, tokenID); err != nil {
+		if errors.Is(err, repository.ErrPersonalTokenNotFound) {
+			return ErrPersonalTokenNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// VerifyPersonalToken은 Personal Access Token을 확인합니다. (auth.PersonalTokenVerifier 구현)
+//
+//	사용자 정보는 매 요청 DB에서 읽으므로 역할 변경이 바로 반영됩니다.
+func (s *authService) VerifyPersonalToken(ctx context.Context, raw string) (*auth.CustomClaims, error) {
+	token, err := s.personalTokenRepo.FindByHash(ctx, auth.HashPersonalToken(raw))
+	if err != nil {
+		if errors.Is(err, repository.ErrPersonalTokenNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if token.IsExpired(now) {
+		return nil, auth.ErrExpiredToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err := checkSuspended(user); err != nil {
+		return nil, fmt.Errorf("%w: %w", auth.ErrInvalidToken, err) // 정지 중에는 토큰 사용 불가
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= personalTokenTouchInterval {
+		if err := s.personalTokenRepo.TouchLastUsed(ctx, token.ID, now); err != nil {
+			// 기록 실패로 요청을 막지는 않음
+			slog.WarnContext(ctx, "failed to update personal token last used", "token_id", token.ID, "error", err)
+		}
+	}
+
+	return &auth.CustomClaims{
+		UserID:          user.ID,
+		Email:           user.Email,
+		Username:        user.Username,
+		Role:            string(user.Role),
+		EmailVerified:   user.IsEmailVerified(),
+		PersonalTokenID: token.ID,
+		Scopes:          token.ScopeList(),
+	}, nil
+}
+
+func personalTokenResponse(token *domain.PersonalAccessToken) dto.PersonalTokenResponse {
+	return dto.PersonalTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
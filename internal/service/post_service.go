@@ -3,13 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"gorm-test/internal/auth"
 	"gorm-test/internal/config"
 	"gorm-test/internal/domain"
 	"gorm-test/internal/dto"
+	"gorm-test/internal/filter"
 	"gorm-test/internal/repository"
 	"gorm-test/middleware"
 	"gorm-test/pkg/apperror"
 	"gorm-test/pkg/metrics"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
@@ -40,14 +43,18 @@ type PostItem struct {
 }
 
 type PostService struct {
-	postRepo repository.PostRepository
-	cfg      *config.Config
+	postRepo      repository.PostRepository
+	cfg           *config.Config
+	contentFilter *filter.Pipeline
+	permissions   auth.PermissionChecker
 }
 
-func NewPostService(postRepo repository.PostRepository, cfg *config.Config) *PostService {
+func NewPostService(postRepo repository.PostRepository, cfg *config.Config, contentFilter *filter.Pipeline, permissions auth.PermissionChecker) *PostService {
 	return &PostService{
-		postRepo: postRepo,
-		cfg:      cfg,
+		postRepo:      postRepo,
+		cfg:           cfg,
+		contentFilter: contentFilter,
+		permissions:   permissions,
 	}
 }
 
@@ -57,6 +64,24 @@ func (s *PostService) Create(ctx context.Context, req *dto.CreatePostRequest) (*
 		return nil, ErrUnauthorized
 	}
 
+	// 스팸/어뷰징 필터
+	result, err := s.contentFilter.Run(ctx, &filter.Content{
+		Target: filter.TargetPost,
+		UserID: claims.UserID,
+		Text:   req.Title + "\n" + req.Content,
+	})
+	if err != nil {
+		return nil, apperror.InternalError(err).WithDetail("콘텐츠 필터 실행 실패")
+	}
+	if result.Decision == filter.DecisionReject {
+		return nil, filter.RejectError(result)
+	}
+
+	status := domain.ModerationApproved
+	if result.Decision == filter.DecisionHold {
+		status = domain.ModerationPending
+	}
+
 	start := time.Now()
 
 	// 중복 제목 검사
@@ -69,24 +94,34 @@ func (s *PostService) Create(ctx context.Context, req *dto.CreatePostRequest) (*
 		Title:    req.Title,
 		Content:  req.Content,
 		AuthorID: claims.UserID,
+		Status:   status,
 	}
-	err := s.postRepo.Create(post)
+	err = s.postRepo.Create(post)
 
 	// DB 쿼리 시간 기록
 	metrics.DBQueryDuration.WithLabelValues("insert", "posts").
 		Observe(time.Since(start).Seconds())
 
 	if err != nil {
+		result.Release()
 		return nil, apperror.InternalError(err).WithDetail("게시글 생성 실패")
 	}
 
-	// 게시글 생성 카운터 증가
+	// 응답에 작성자 이름을 넣기 위해 다시 조회
+	if created, err := s.postRepo.FindByID(post.ID); err == nil {
+		post = created
+	}
+
+	// 게시글 생성 카운터 증가 (게시글 수는 공개된 게시글만 집계)
 	metrics.PostsCreated.Inc()
-	metrics.PostsTotal.Inc()
+	if post.Status == domain.ModerationApproved {
+		metrics.PostsTotal.Inc()
+	}
 	return s.toResponse(post), nil
 }
 
-func (s *PostService) GetByID(id uint) (*dto.PostResponse, error) {
+// GetByID 게시글 조회 - 검토 대기 중인 게시글은 작성자와 post.moderate 권한이 있는 역할만 볼 수 있음
+func (s *PostService) GetByID(ctx context.Context, id uint) (*dto.PostResponse, error) {
 	post, err := s.postRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -95,6 +130,18 @@ func (s *PostService) GetByID(id uint) (*dto.PostResponse, error) {
 		return nil, apperror.InternalError(err).WithDetail("게시글 조회 중 오류")
 	}
 
+	if post.Status != domain.ModerationApproved {
+		visible, err := s.canViewUnapproved(ctx, post)
+		if err != nil {
+			return nil, apperror.InternalError(err).WithDetail("게시글 권한 확인 중 오류")
+		}
+		if !visible {
+			// 보류된 게시글이 있다는 것도 알 수 없도록 404
+			return nil, apperror.NotFoundWithID("게시글", id)
+		}
+		return s.toResponse(post), nil // 공개 전에는 조회수를 세지 않음
+	}
+
 	_ = s.postRepo.IncrementViews(id)
 	post.Views++
 
@@ -117,11 +164,12 @@ func (s *PostService) GetList(page, size int, search *dto.SearchParams, sort *dt
 	list := make([]dto.PostListResponse, len(posts))
 	for i, post := range posts {
 		list[i] = dto.PostListResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Author:    post.Author.Username,
-			Views:     post.Views,
-			CreatedAt: post.CreatedAt,
+			ID:           post.ID,
+			Title:        post.Title,
+			Author:       authorName(&post),
+			Views:        post.Views,
+			CommentCount: post.CommentCount,
+			CreatedAt:    post.CreatedAt,
 		}
 	}
 
@@ -175,11 +223,12 @@ func (s *PostService) GetListByCursor(cursorStr string, size int) ([]dto.PostLis
 	list := make([]dto.PostListResponse, len(posts))
 	for i, post := range posts {
 		list[i] = dto.PostListResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Author:    post.Author.Username,
-			Views:     post.Views,
-			CreatedAt: post.CreatedAt,
+			ID:           post.ID,
+			Title:        post.Title,
+			Author:       authorName(&post),
+			Views:        post.Views,
+			CommentCount: post.CommentCount,
+			CreatedAt:    post.CreatedAt,
 		}
 	}
 
@@ -213,9 +262,11 @@ func (s *PostService) Update(ctx context.Context, id uint, req *dto.UpdatePostRe
 		return nil, err
 	}
 
-	// 권한 검사: 작성자 본인 또는 관리자만 수정 가능
-	if post.AuthorID != claims.UserID && claims.Role != "admin" {
-		return nil, ErrForbidden
+	// 권한 검사: 작성자 본인 또는 post.edit.any 권한이 있는 역할만 수정 가능
+	if post.AuthorID != claims.UserID {
+		if err := requirePermission(ctx, s.permissions, claims, domain.PermPostEditAny); err != nil {
+			return nil, err
+		}
 	}
 	// 작성자 확인
 	//if post.AuthorID != userID {
@@ -247,22 +298,162 @@ func (s *PostService) Delete(ctx context.Context, id uint) error {
 	}
 
 	// 권한 검사
-	if post.AuthorID != claims.UserID && claims.Role != "admin" {
-		return ErrForbidden
+	if post.AuthorID != claims.UserID {
+		if err := requirePermission(ctx, s.permissions, claims, domain.PermPostDeleteAny); err != nil {
+			return err
+		}
+	}
+
+	if err := s.postRepo.Delete(id); err != nil {
+		return err
+	}
+
+	// 삭제된 게시글의 댓글은 더 이상 집계하지 않음
+	if post.Status == domain.ModerationApproved {
+		metrics.PostsTotal.Dec()
+	}
+	metrics.CommentsTotal.Sub(float64(post.CommentCount))
+	return nil
+}
+
+// ListPending 검토 대기 게시글 목록 (관리자)
+func (s *PostService) ListPending(pagination *dto.Pagination) ([]*dto.PostResponse, *dto.Meta, error) {
+	posts, total, err := s.postRepo.FindPending(pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := make([]*dto.PostResponse, len(posts))
+	for i := range posts {
+		list[i] = s.toResponse(&posts[i])
+	}
+
+	meta := &dto.Meta{
+		Page:       pagination.Page,
+		Size:       pagination.Size,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Size))),
+	}
+	return list, meta, nil
+}
+
+// Approve 검토 대기 게시글 승인 (공개, 관리자)
+func (s *PostService) Approve(id uint) (*dto.PostResponse, error) {
+	post, err := s.postRepo.Approve(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
 	}
 
-	return s.postRepo.Delete(id)
+	metrics.PostsTotal.Inc()
+	return s.toResponse(post), nil
+}
+
+// Reject 검토 대기 게시글 거부 (삭제, 관리자)
+func (s *PostService) Reject(id uint) error {
+	post, err := s.postRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+
+	if err := s.postRepo.Reject(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+
+	// 삭제된 게시글의 댓글은 더 이상 집계하지 않음
+	metrics.CommentsTotal.Sub(float64(post.CommentCount))
+	return nil
+}
+
+// SetCommentLock 댓글 잠금/해제 - 작성자 본인 또는 post.lock.any 권한이 있는 역할만 가능
+func (s *PostService) SetCommentLock(ctx context.Context, id uint, req *dto.LockCommentsRequest) (*dto.PostResponse, error) {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	post, err := s.postRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	// 권한 검사
+	if post.AuthorID != claims.UserID {
+		if err := requirePermission(ctx, s.permissions, claims, domain.PermPostLockAny); err != nil {
+			return nil, err
+		}
+	}
+
+	if *req.Locked && req.UnlockAt != nil && !req.UnlockAt.After(time.Now()) {
+		return nil, apperror.BadRequest("자동 해제 시각은 현재 이후여야 합니다")
+	}
+
+	post.CommentsLocked = *req.Locked
+	post.CommentsUnlockAt = nil
+	if post.CommentsLocked {
+		post.CommentsUnlockAt = req.UnlockAt
+	}
+
+	if err := s.postRepo.Update(post); err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(post), nil
+}
+
+// canViewUnapproved는 요청한 사용자가 공개되지 않은 게시글을 볼 수 있는지 확인합니다.
+func (s *PostService) canViewUnapproved(ctx context.Context, post *domain.Post) (bool, error) {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	if post.AuthorID == claims.UserID {
+		return true, nil
+	}
+	if s.permissions == nil {
+		return false, nil
+	}
+	return s.permissions.HasPermission(ctx, claims.Role, domain.PermPostModerate)
+}
+
+// authorName은 게시글 작성자 이름입니다. 작성자가 삭제되어 조회되지 않으면 domain.DeletedUsername
+func authorName(post *domain.Post) string {
+	if post.Author == nil {
+		return domain.DeletedUsername
+	}
+	return post.Author.Username
 }
 
 func (s *PostService) toResponse(post *domain.Post) *dto.PostResponse {
+	locked := post.IsCommentsLocked(time.Now())
+	var unlockAt *time.Time
+	if locked {
+		unlockAt = post.CommentsUnlockAt
+	}
+
 	return &dto.PostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		Author:    post.Author.Username,
-		Views:     post.Views,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+		ID:               post.ID,
+		Title:            post.Title,
+		Content:          post.Content,
+		Author:           authorName(post),
+		Views:            post.Views,
+		CommentCount:     post.CommentCount,
+		Status:           string(post.Status),
+		CommentsLocked:   locked,
+		CommentsUnlockAt: unlockAt,
+		CreatedAt:        post.CreatedAt,
+		UpdatedAt:        post.UpdatedAt,
 	}
 }
 
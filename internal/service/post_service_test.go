@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryPostRepository는 테스트에 필요한 PostRepository 메서드만 구현합니다.
+type memoryPostRepository struct {
+	repository.PostRepository
+
+	mu    sync.Mutex
+	posts map[uint]*domain.Post
+}
+
+func newMemoryPostRepository(posts ...*domain.Post) *memoryPostRepository {
+	r := &memoryPostRepository{posts: make(map[uint]*domain.Post)}
+	for _, post := range posts {
+		r.posts[post.ID] = post
+	}
+	return r
+}
+
+func (r *memoryPostRepository) FindByID(id uint) (*domain.Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	clone := *post
+	return &clone, nil
+}
+
+func (r *memoryPostRepository) IncrementViews(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posts[id].Views++
+	return nil
+}
+
+func pendingPost() *domain.Post {
+	return &domain.Post{
+		ID:       1,
+		Title:    "보류된 게시글",
+		AuthorID: 3,
+		Author:   &domain.User{ID: 3, Username: "author"},
+		Status:   domain.ModerationPending,
+	}
+}
+
+func TestPostService_GetByIDHidesPendingPosts(t *testing.T) {
+	posts := newMemoryPostRepository(pendingPost())
+	s := NewPostService(posts, nil, nil, newTestRoleService(t, newMemoryUserRepository()))
+
+	for name, ctx := range map[string]context.Context{
+		"비로그인":   context.Background(),
+		"다른 사용자": as(4, domain.RoleUser),
+	} {
+		_, err := s.GetByID(ctx, 1)
+		assert.Equal(t, http.StatusNotFound, apperror.GetHTTPStatus(err), name)
+	}
+
+	// 작성자와 post.moderate 권한이 있는 역할은 조회 가능
+	for name, ctx := range map[string]context.Context{
+		"작성자":       as(3, domain.RoleUser),
+		"moderator": as(1, "moderator"),
+	} {
+		post, err := s.GetByID(ctx, 1)
+		require.NoError(t, err, name)
+		assert.Equal(t, string(domain.ModerationPending), post.Status, name)
+	}
+
+	// 공개 전에는 조회수를 세지 않음
+	stored, err := posts.FindByID(1)
+	require.NoError(t, err)
+	assert.Zero(t, stored.Views)
+}
+
+func TestPostService_GetByIDApprovedPost(t *testing.T) {
+	post := pendingPost()
+	post.Status = domain.ModerationApproved
+	s := NewPostService(newMemoryPostRepository(post), nil, nil, nil)
+
+	resp, err := s.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Views)
+	assert.Equal(t, "author", resp.Author)
+}
+
+func TestCommentService_RejectsUnapprovedPosts(t *testing.T) {
+	s := NewCommentService(nil, newMemoryPostRepository(pendingPost()), nil, nil)
+
+	// 작성자도 승인 전에는 댓글 작성 불가
+	_, err := s.Create(as(3, domain.RoleUser), 1, &dto.CreateCommentRequest{Content: "댓글", Author: "author"})
+	assert.ErrorIs(t, err, ErrPostNotExists)
+
+	_, err = s.GetByPostID(1)
+	assert.ErrorIs(t, err, ErrPostNotExists)
+}
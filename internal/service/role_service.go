@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// permissionCacheTTL 동안 역할별 권한을 메모리에 캐시합니다.
+// 다른 인스턴스에서 역할을 수정해도 이 시간 안에 반영됩니다.
+const permissionCacheTTL = 30 * time.Second
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,19}$`)
+
+// defaultRoles는 역할 테이블이 비어 있을 때 만드는 기본 역할입니다.
+var defaultRoles = []struct {
+	name        domain.Role
+	description string
+	system      bool
+	permissions []domain.Permission
+}{
+	{domain.RoleUser, "일반 사용자", true, nil},
+	{domain.RoleAdmin, "관리자 (모든 권한)", true, domain.AllPermissions()},
+	{"moderator", "게시글/댓글 관리", false, []domain.Permission{
+		domain.PermPostEditAny, domain.PermPostDeleteAny, domain.PermPostLockAny, domain.PermPostModerate,
+		domain.PermCommentEditAny, domain.PermCommentDeleteAny, domain.PermCommentModerate,
+		domain.PermUserRead,
+	}},
+}
+
+// RoleService는 역할과 권한을 관리하고, 역할의 권한을 확인합니다. (auth.PermissionChecker 구현)
+type RoleService struct {
+	roleRepo     repository.RoleRepository
+	userRepo     repository.UserRepository
+	tokenService *auth.TokenService
+	audit        *AuditService
+
+	mu          sync.RWMutex
+	permissions map[string]map[domain.Permission]bool // 역할 -> 권한 (캐시)
+	loadedAt    time.Time
+}
+
+func NewRoleService(roleRepo repository.RoleRepository, userRepo repository.UserRepository, tokenService *auth.TokenService, audit *AuditService) *RoleService {
+	return &RoleService{
+		roleRepo:     roleRepo,
+		userRepo:     userRepo,
+		tokenService: tokenService,
+		audit:        audit,
+	}
+}
+
+// EnsureDefaultRoles는 기본 역할을 만들고, admin 역할에 새로 추가된 권한을 반영합니다.
+// 서버 시작 시 호출합니다.
+func (s *RoleService) EnsureDefaultRoles(ctx context.Context) error {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	firstRun := len(roles) == 0
+
+	for _, def := range defaultRoles {
+		if !def.system && !firstRun {
+			continue // 시스템 역할이 아닌 기본 역할은 처음 한 번만 (삭제했으면 다시 만들지 않음)
+		}
+
+		role, err := s.roleRepo.FindByName(ctx, def.name)
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			role = &domain.RoleDefinition{Name: def.name, Description: def.description, System: def.system}
+			role.SetPermissions(def.permissions)
+			if err := s.roleRepo.Create(ctx, role); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if def.name == domain.RoleAdmin && !slices.Equal(role.PermissionList(), slices.Sorted(slices.Values(def.permissions))) {
+			role.SetPermissions(def.permissions)
+			if err := s.roleRepo.Update(ctx, role); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// HasPermission은 역할에 권한이 있는지 확인합니다.
+// admin 역할은 항상 모든 권한을 가집니다.
+func (s *RoleService) HasPermission(ctx context.Context, role string, permission domain.Permission) (bool, error) {
+	if role == string(domain.RoleAdmin) {
+		return true, nil
+	}
+
+	permissions, err := s.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	return permissions[role][permission], nil
+}
+
+// load는 캐시가 만료되었으면 역할별 권한을 다시 읽습니다.
+func (s *RoleService) load(ctx context.Context) (map[string]map[domain.Permission]bool, error) {
+	s.mu.RLock()
+	if s.permissions != nil && time.Since(s.loadedAt) < permissionCacheTTL {
+		permissions := s.permissions
+		s.mu.RUnlock()
+		return permissions, nil
+	}
+	s.mu.RUnlock()
+
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string]map[domain.Permission]bool, len(roles))
+	for _, role := range roles {
+		set := make(map[domain.Permission]bool, len(role.Permissions))
+		for _, p := range role.Permissions {
+			set[p.Permission] = true
+		}
+		permissions[string(role.Name)] = set
+	}
+
+	s.mu.Lock()
+	s.permissions = permissions
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return permissions, nil
+}
+
+func (s *RoleService) invalidate() {
+	s.mu.Lock()
+	s.permissions = nil
+	s.mu.Unlock()
+}
+
+// ListPermissions 정의된 권한 목록
+func (s *RoleService) ListPermissions() []domain.PermissionInfo {
+	return domain.Permissions
+}
+
+// List 역할 목록
+func (s *RoleService) List(ctx context.Context) ([]*dto.RoleResponse, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		resp, err := s.toResponse(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp)
+	}
+	return result, nil
+}
+
+// Create 역할 생성
+func (s *RoleService) Create(ctx context.Context, req *dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, apperror.ValidationError(map[string]string{
+			"name": "영문 소문자로 시작하는 2~20자의 영문 소문자, 숫자, -, _만 사용할 수 있습니다",
+		})
+	}
+
+	permissions, err := s.parsePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.roleRepo.ExistsByName(ctx, domain.Role(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperror.Conflict("이미 존재하는 역할입니다")
+	}
+
+	role := &domain.RoleDefinition{
+		Name:        domain.Role(req.Name),
+		Description: req.Description,
+	}
+	role.SetPermissions(permissions)
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditRoleCreated,
+		Metadata: map[string]any{"role": role.Name, "permissions": permissions},
+	})
+
+	return s.toResponse(ctx, role)
+}
+
+// Update 역할 설명/권한 수정 - admin 역할은 수정할 수 없음
+func (s *RoleService) Update(ctx context.Context, name string, req *dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := s.findRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if role.Name == domain.RoleAdmin {
+		return nil, apperror.Forbidden("admin 역할은 항상 모든 권한을 가지므로 수정할 수 없습니다")
+	}
+
+	permissions, err := s.parsePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	// 제거하는 권한도 자신이 가진 권한이어야 함
+	if err := s.requirePermissions(ctx, role.PermissionList()); err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	previous := role.PermissionList()
+	role.SetPermissions(permissions)
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditRoleUpdated,
+		Metadata: map[string]any{"role": role.Name, "from": previous, "to": permissions},
+	})
+
+	return s.toResponse(ctx, role)
+}
+
+// Delete 역할 삭제 - 시스템 역할이나 사용자가 있는 역할은 삭제할 수 없음
+func (s *RoleService) Delete(ctx context.Context, name string) error {
+	role, err := s.findRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.System {
+		return apperror.Forbidden("기본 역할은 삭제할 수 없습니다")
+	}
+	if err := s.requirePermissions(ctx, role.PermissionList()); err != nil {
+		return err
+	}
+
+	count, err := s.roleRepo.CountUsers(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperror.Conflict("역할이 지정된 사용자가 있어 삭제할 수 없습니다").
+			WithDetail(fmt.Sprintf("사용자 %d명", count))
+	}
+
+	if err := s.roleRepo.Delete(ctx, role.ID); err != nil {
+		return err
+	}
+	s.invalidate()
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditRoleDeleted, Metadata: map[string]any{"role": role.Name}})
+	return nil
+}
+
+// AssignRole 사용자 역할 변경
+//
+//	자신의 역할은 바꿀 수 없고, 자신이 가진 권한보다 많은 권한을 가진 역할은 부여하거나 회수할 수 없습니다.
+//	토큰에 이전 역할이 남아 있으므로 대상 사용자의 모든 세션을 종료합니다.
+func (s *RoleService) AssignRole(ctx context.Context, userID uint, name string) error {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+	if claims.UserID == userID {
+		return apperror.Forbidden("자신의 역할은 변경할 수 없습니다")
+	}
+
+	role, err := s.findRole(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NotFoundWithID("사용자", userID)
+		}
+		return err
+	}
+	if user.Role == role.Name {
+		return nil
+	}
+
+	current, err := s.roleRepo.FindByName(ctx, user.Role)
+	if err != nil && !errors.Is(err, repository.ErrRoleNotFound) {
+		return err
+	}
+	if err := s.requireRole(ctx, role); err != nil {
+		return err
+	}
+	if current != nil {
+		if err := s.requireRole(ctx, current); err != nil {
+			return err
+		}
+	}
+
+	previous := user.Role
+	user.Role = role.Name
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditRoleChanged,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"from": previous, "to": role.Name},
+	})
+
+	return s.tokenService.RevokeAllSessions(ctx, user.ID)
+}
+
+func (s *RoleService) findRole(ctx context.Context, name string) (*domain.RoleDefinition, error) {
+	role, err := s.roleRepo.FindByName(ctx, domain.Role(name))
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return nil, apperror.NotFound("역할")
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// parsePermissions는 권한 이름을 검증합니다. 자신이 가진 권한만 부여할 수 있습니다.
+func (s *RoleService) parsePermissions(ctx context.Context, names []string) ([]domain.Permission, error) {
+	permissions := make([]domain.Permission, 0, len(names))
+	for _, name := range names {
+		p := domain.Permission(name)
+		if !p.IsValid() {
+			return nil, apperror.ValidationError(map[string]string{
+				"permissions": fmt.Sprintf("알 수 없는 권한입니다: %s", name),
+			})
+		}
+		permissions = append(permissions, p)
+	}
+
+	if err := s.requirePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// CheckAuthority는 요청한 사용자가 role을 가진 사용자를 관리(정지, 삭제 등)할 수 있는지 확인합니다.
+// role의 권한을 모두 가지고 있어야 합니다. (admin은 admin만 관리 가능)
+func (s *RoleService) CheckAuthority(ctx context.Context, role domain.Role) error {
+	def, err := s.roleRepo.FindByName(ctx, role)
+	if errors.Is(err, repository.ErrRoleNotFound) {
+		def = &domain.RoleDefinition{Name: role} // 삭제된 역할 - 권한 없음
+	} else if err != nil {
+		return err
+	}
+
+	if err := s.requireRole(ctx, def); err != nil {
+		if apperror.IsAppError(err) {
+			return apperror.Forbidden("자신보다 권한이 많은 사용자는 관리할 수 없습니다")
+		}
+		return err
+	}
+	return nil
+}
+
+// requireRole은 요청한 사용자가 역할의 권한을 모두 가지고 있는지 확인합니다.
+func (s *RoleService) requireRole(ctx context.Context, role *domain.RoleDefinition) error {
+	if role.Name == domain.RoleAdmin {
+		return s.requirePermissions(ctx, domain.AllPermissions())
+	}
+	return s.requirePermissions(ctx, role.PermissionList())
+}
+
+// requirePermissions는 요청한 사용자가 permissions를 모두 가지고 있는지 확인합니다. (권한 상승 방지)
+func (s *RoleService) requirePermissions(ctx context.Context, permissions []domain.Permission) error {
+	claims, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	for _, p := range permissions {
+		allowed, err := s.HasPermission(ctx, claims.Role, p)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return apperror.Forbidden("자신에게 없는 권한은 부여하거나 회수할 수 없습니다").
+				WithDetail(string(p))
+		}
+	}
+	return nil
+}
+
+func (s *RoleService) toResponse(ctx context.Context, role *domain.RoleDefinition) (*dto.RoleResponse, error) {
+	count, err := s.roleRepo.CountUsers(ctx, role.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := role.PermissionList()
+	names := make([]string, len(permissions))
+	for i, p := range permissions {
+		names[i] = string(p)
+	}
+
+	return &dto.RoleResponse{
+		ID:          role.ID,
+		Name:        string(role.Name),
+		Description: role.Description,
+		System:      role.System,
+		Permissions: names,
+		UserCount:   count,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}, nil
+}
+
+// requirePermission은 claims의 역할에 permission이 없으면 ErrForbidden을 반환합니다.
+// 본인 리소스가 아닐 때 "다른 사용자 리소스" 권한을 확인하는 데 사용합니다.
+// hasAdminPermission은 역할에 관리자 API 권한이 하나라도 있는지 확인합니다. (checker가 nil이면 false)
+// 역할 이름이 아니라 권한으로 판단하므로 관리자 권한을 받은 사용자 정의 역할도 관리자로 취급합니다.
+func hasAdminPermission(ctx context.Context, checker auth.PermissionChecker, role string) (bool, error) {
+	if checker == nil {
+		return false, nil
+	}
+	for _, permission := range domain.AllPermissions() {
+		allowed, err := checker.HasPermission(ctx, role, permission)
+		if err != nil {
+			return false, err
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func requirePermission(ctx context.Context, checker auth.PermissionChecker, claims *auth.CustomClaims, permission domain.Permission) error {
+	if checker == nil {
+		return ErrForbidden
+	}
+	allowed, err := checker.HasPermission(ctx, claims.Role, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm-test/internal/auth"
+	"gorm-test/internal/config"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/middleware"
+	"gorm-test/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRoleRepository는 역할을 메모리에 보관합니다.
+type memoryRoleRepository struct {
+	mu     sync.Mutex
+	nextID uint
+	roles  map[domain.Role]*domain.RoleDefinition
+	users  *memoryUserRepository // CountUsers용
+}
+
+func newMemoryRoleRepository(users *memoryUserRepository) *memoryRoleRepository {
+	return &memoryRoleRepository{roles: make(map[domain.Role]*domain.RoleDefinition), users: users}
+}
+
+func (r *memoryRoleRepository) Create(ctx context.Context, role *domain.RoleDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	role.ID = r.nextID
+	r.roles[role.Name] = role
+	return nil
+}
+
+func (r *memoryRoleRepository) FindByName(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	role, ok := r.roles[name]
+	if !ok {
+		return nil, repository.ErrRoleNotFound
+	}
+	clone := *role
+	return &clone, nil
+}
+
+func (r *memoryRoleRepository) List(ctx context.Context) ([]*domain.RoleDefinition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	roles := make([]*domain.RoleDefinition, 0, len(r.roles))
+	for _, role := range r.roles {
+		clone := *role
+		roles = append(roles, &clone)
+	}
+	return roles, nil
+}
+
+func (r *memoryRoleRepository) Update(ctx context.Context, role *domain.RoleDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *role
+	r.roles[role.Name] = &clone
+	return nil
+}
+
+func (r *memoryRoleRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, role := range r.roles {
+		if role.ID == id {
+			delete(r.roles, name)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRoleRepository) ExistsByName(ctx context.Context, name domain.Role) (bool, error) {
+	_, err := r.FindByName(ctx, name)
+	return err == nil, nil
+}
+
+func (r *memoryRoleRepository) CountUsers(ctx context.Context, name domain.Role) (int64, error) {
+	return r.users.countRole(name), nil
+}
+
+// memoryUserRepository는 테스트에 필요한 UserRepository 메서드만 구현합니다.
+type memoryUserRepository struct {
+	repository.UserRepository
+
+	mu    sync.Mutex
+	users map[uint]*domain.User
+}
+
+func newMemoryUserRepository(users ...*domain.User) *memoryUserRepository {
+	r := &memoryUserRepository{users: make(map[uint]*domain.User)}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *memoryUserRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	clone := *user
+	return &clone, nil
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *user
+	r.users[user.ID] = &clone
+	return nil
+}
+
+func (r *memoryUserRepository) countRole(role domain.Role) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, user := range r.users {
+		if user.Role == role {
+			count++
+		}
+	}
+	return count
+}
+
+// newTestTokenService는 메모리 키/세션 저장소를 쓰는 TokenService를 만듭니다.
+func newTestTokenService(t *testing.T) *auth.TokenService {
+	t.Helper()
+	keys, err := auth.NewKeyManager(context.Background(), auth.NewMemoryKeyStore(), auth.KeyManagerConfig{Algorithm: auth.AlgEdDSA})
+	require.NoError(t, err)
+
+	store := auth.NewMemoryTokenStore(time.Hour)
+	t.Cleanup(store.Close)
+	return auth.NewTokenService(keys, 15*time.Minute, 24*time.Hour, store)
+}
+
+// newTestRoleService는 기본 역할과 support 역할(user.read, user.ban)이 있는 RoleService를 만듭니다.
+func newTestRoleService(t *testing.T, users *memoryUserRepository) *RoleService {
+	t.Helper()
+	ctx := context.Background()
+	roles := newMemoryRoleRepository(users)
+
+	s := NewRoleService(roles, users, newTestTokenService(t), nil)
+	require.NoError(t, s.EnsureDefaultRoles(ctx))
+
+	support := &domain.RoleDefinition{Name: "support"}
+	support.SetPermissions([]domain.Permission{domain.PermUserRead, domain.PermUserBan})
+	require.NoError(t, roles.Create(ctx, support))
+	return s
+}
+
+// as는 role 역할의 사용자로 요청한 context를 만듭니다.
+func as(userID uint, role domain.Role) context.Context {
+	return middleware.SetUserToContext(context.Background(), &auth.CustomClaims{UserID: userID, Role: string(role)})
+}
+
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, apperror.GetHTTPStatus(err), err.Error())
+}
+
+func TestRoleService_CreateCannotGrantMissingPermissions(t *testing.T) {
+	s := newTestRoleService(t, newMemoryUserRepository())
+	moderator := as(1, "moderator")
+
+	_, err := s.Create(moderator, &dto.CreateRoleRequest{Name: "helper", Permissions: []string{"post.edit.any", "user.ban"}})
+	assertForbidden(t, err)
+
+	role, err := s.Create(moderator, &dto.CreateRoleRequest{Name: "helper", Permissions: []string{"post.edit.any"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"post.edit.any"}, role.Permissions)
+}
+
+func TestRoleService_UpdateCannotTouchMissingPermissions(t *testing.T) {
+	s := newTestRoleService(t, newMemoryUserRepository())
+	moderator := as(1, "moderator")
+
+	// support의 user.ban은 moderator에게 없으므로 회수도 불가
+	_, err := s.Update(moderator, "support", &dto.UpdateRoleRequest{Permissions: []string{"user.read"}})
+	assertForbidden(t, err)
+
+	// 자신의 역할에 없는 권한 추가 불가
+	_, err = s.Update(moderator, "moderator", &dto.UpdateRoleRequest{Permissions: []string{"post.edit.any", "role.assign"}})
+	assertForbidden(t, err)
+
+	// admin 역할은 admin도 수정 불가
+	_, err = s.Update(as(2, domain.RoleAdmin), string(domain.RoleAdmin), &dto.UpdateRoleRequest{Permissions: []string{}})
+	assertForbidden(t, err)
+
+	_, err = s.Update(as(2, domain.RoleAdmin), "support", &dto.UpdateRoleRequest{Permissions: []string{"user.read"}})
+	assert.NoError(t, err)
+}
+
+func TestRoleService_DeleteGuards(t *testing.T) {
+	s := newTestRoleService(t, newMemoryUserRepository())
+
+	assertForbidden(t, s.Delete(as(1, "moderator"), "support"))
+	assertForbidden(t, s.Delete(as(2, domain.RoleAdmin), string(domain.RoleUser))) // 시스템 역할
+	assert.NoError(t, s.Delete(as(2, domain.RoleAdmin), "support"))
+}
+
+func TestRoleService_AssignRoleGuards(t *testing.T) {
+	users := newMemoryUserRepository(
+		&domain.User{ID: 1, Email: "mod@example.com", Role: "moderator"},
+		&domain.User{ID: 2, Email: "admin@example.com", Role: domain.RoleAdmin},
+		&domain.User{ID: 3, Email: "user@example.com", Role: domain.RoleUser},
+	)
+	s := newTestRoleService(t, users)
+	moderator := as(1, "moderator")
+
+	// 자신의 역할 변경 불가
+	assertForbidden(t, s.AssignRole(as(2, domain.RoleAdmin), 2, string(domain.RoleUser)))
+
+	// 자신보다 권한이 많은 역할 부여 불가
+	assertForbidden(t, s.AssignRole(moderator, 3, string(domain.RoleAdmin)))
+	assertForbidden(t, s.AssignRole(moderator, 3, "support"))
+
+	// 자신보다 권한이 많은 사용자의 역할 회수 불가
+	assertForbidden(t, s.AssignRole(moderator, 2, string(domain.RoleUser)))
+
+	unchanged, err := users.FindByID(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, unchanged.Role)
+}
+
+func TestRoleService_AssignRoleRevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	users := newMemoryUserRepository(&domain.User{ID: 3, Email: "user@example.com", Role: domain.RoleUser})
+	s := newTestRoleService(t, users)
+
+	_, _, err := s.tokenService.CreateSession(ctx, 3, auth.SessionMeta{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.AssignRole(as(2, domain.RoleAdmin), 3, "support"))
+
+	user, err := users.FindByID(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Role("support"), user.Role)
+
+	// 이전 역할이 담긴 토큰은 더 이상 갱신할 수 없음
+	sessions, err := s.tokenService.ListSessions(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestRoleService_CheckAuthority(t *testing.T) {
+	s := newTestRoleService(t, newMemoryUserRepository())
+
+	assertForbidden(t, s.CheckAuthority(as(1, "moderator"), domain.RoleAdmin))
+	assertForbidden(t, s.CheckAuthority(as(1, "moderator"), "support"))
+	assert.NoError(t, s.CheckAuthority(as(1, "moderator"), domain.RoleUser))
+	assert.NoError(t, s.CheckAuthority(as(2, domain.RoleAdmin), "moderator"))
+
+	// 삭제된 역할은 권한이 없는 것으로 취급
+	assert.NoError(t, s.CheckAuthority(as(1, "moderator"), "deleted"))
+}
+
+func TestHasAdminPermission(t *testing.T) {
+	ctx := context.Background()
+	roles := newTestRoleService(t, newMemoryUserRepository())
+
+	for role, want := range map[domain.Role]bool{
+		domain.RoleAdmin: true,
+		"moderator":      true, // 관리자 API 권한이 하나라도 있으면 관리자로 취급
+		"support":        true,
+		domain.RoleUser:  false,
+	} {
+		allowed, err := hasAdminPermission(ctx, roles, string(role))
+		require.NoError(t, err)
+		assert.Equal(t, want, allowed, role)
+	}
+
+	// 권한 확인기가 없으면 허용하지 않음
+	allowed, err := hasAdminPermission(ctx, nil, string(domain.RoleAdmin))
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestDisableTwoFactor_RequiredForCustomAdminRoles(t *testing.T) {
+	enabledAt := time.Now()
+	users := newMemoryUserRepository(&domain.User{ID: 1, Email: "support@example.com", Role: "support", TOTPEnabledAt: &enabledAt})
+	s := &authService{
+		userRepo:    users,
+		permissions: newTestRoleService(t, users),
+		cfg:         config.AuthConfig{RequireAdmin2FA: true},
+	}
+
+	// 역할 이름이 admin이 아니어도 관리자 권한이 있으면 해제 불가
+	err := s.DisableTwoFactor(context.Background(), 1, &dto.TwoFactorDisableRequest{})
+	assert.ErrorIs(t, err, ErrTwoFactorRequired)
+}
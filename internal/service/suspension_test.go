@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLoginEventRepository는 로그인 기록을 메모리에 남깁니다.
+type memoryLoginEventRepository struct {
+	repository.LoginEventRepository
+
+	mu     sync.Mutex
+	events []domain.LoginEvent
+}
+
+func (r *memoryLoginEventRepository) Create(ctx context.Context, event *domain.LoginEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *memoryLoginEventRepository) CheckDevice(ctx context.Context, userID uint, userAgent, ipRange string) (*repository.DeviceHistory, error) {
+	return &repository.DeviceHistory{}, nil
+}
+
+// stubPersonalTokenRepository는 토큰 하나만 가진 저장소입니다.
+type stubPersonalTokenRepository struct {
+	repository.PersonalAccessTokenRepository
+	token *domain.PersonalAccessToken
+}
+
+func (r *stubPersonalTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	if r.token == nil || r.token.TokenHash != tokenHash {
+		return nil, repository.ErrPersonalTokenNotFound
+	}
+	return r.token, nil
+}
+
+func (r *stubPersonalTokenRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return nil
+}
+
+func (r *stubPersonalTokenRepository) DeleteAllByUser(ctx context.Context, userID uint) error {
+	return nil
+}
+
+func suspendedUser(id uint, until *time.Time) *domain.User {
+	now := time.Now()
+	return &domain.User{
+		ID:             id,
+		Email:          "suspended@example.com",
+		Role:           domain.RoleUser,
+		SuspendedAt:    &now,
+		SuspendedUntil: until,
+		SuspendReason:  "spam",
+	}
+}
+
+func TestUser_IsSuspended(t *testing.T) {
+	now := time.Now()
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+
+	assert.False(t, (&domain.User{}).IsSuspended(now))
+	assert.True(t, suspendedUser(1, nil).IsSuspended(now)) // 영구 정지
+	assert.True(t, suspendedUser(1, &later).IsSuspended(now))
+	assert.False(t, suspendedUser(1, &earlier).IsSuspended(now)) // 기간이 지나면 자동 해제
+}
+
+func TestCompleteLogin_RejectsSuspendedUser(t *testing.T) {
+	ctx := context.Background()
+	until := time.Now().Add(24 * time.Hour)
+	user := suspendedUser(1, &until)
+	events := &memoryLoginEventRepository{}
+	s := &authService{
+		userRepo:       newMemoryUserRepository(user),
+		loginEventRepo: events,
+		tokenService:   newTestTokenService(t),
+	}
+
+	_, err := s.completeLogin(ctx, user, domain.LoginMethodPassword, auth.SessionMeta{IP: "10.0.0.1"})
+	require.ErrorIs(t, err, ErrAccountSuspended)
+	var suspendedErr *SuspendedError
+	require.ErrorAs(t, err, &suspendedErr)
+	assert.Equal(t, "spam", suspendedErr.Reason)
+	assert.Equal(t, &until, suspendedErr.Until)
+
+	// 세션을 만들지 않고 실패로 기록
+	sessions, err := s.tokenService.ListSessions(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+	require.Len(t, events.events, 1)
+	assert.False(t, events.events[0].Success)
+	assert.Equal(t, "suspended", events.events[0].FailureReason)
+
+	// 정지 기간이 끝나면 로그인 가능
+	expired := time.Now().Add(-time.Minute)
+	user.SuspendedUntil = &expired
+	resp, err := s.completeLogin(ctx, user, domain.LoginMethodPassword, auth.SessionMeta{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestRefreshToken_RejectsSuspendedUser(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Email: "user@example.com", Role: domain.RoleUser}
+	users := newMemoryUserRepository(user)
+	s := &authService{userRepo: users, tokenService: newTestTokenService(t)}
+
+	refreshToken, _, err := s.tokenService.CreateSession(ctx, user.ID, auth.SessionMeta{})
+	require.NoError(t, err)
+
+	// 세션 종료 전에 정지된 경우에도 토큰을 갱신할 수 없음
+	require.NoError(t, users.Update(ctx, suspendedUser(user.ID, nil)))
+	_, err = s.RefreshToken(ctx, refreshToken)
+	assert.ErrorIs(t, err, ErrAccountSuspended)
+}
+
+func TestVerifyPersonalToken_RejectsSuspendedUser(t *testing.T) {
+	ctx := context.Background()
+	raw, err := auth.GeneratePersonalToken()
+	require.NoError(t, err)
+
+	users := newMemoryUserRepository(&domain.User{ID: 1, Email: "user@example.com", Role: domain.RoleUser})
+	s := &authService{
+		userRepo: users,
+		personalTokenRepo: &stubPersonalTokenRepository{token: &domain.PersonalAccessToken{
+			ID: 7, UserID: 1, TokenHash: auth.HashPersonalToken(raw), Scopes: "posts:read",
+		}},
+	}
+
+	claims, err := s.VerifyPersonalToken(ctx, raw)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), claims.PersonalTokenID)
+
+	require.NoError(t, users.Update(ctx, suspendedUser(1, nil)))
+	_, err = s.VerifyPersonalToken(ctx, raw)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	assert.ErrorIs(t, err, ErrAccountSuspended)
+}
+
+func TestAdminUserService_Suspend(t *testing.T) {
+	ctx := context.Background()
+	users := newMemoryUserRepository(
+		&domain.User{ID: 1, Email: "mod@example.com", Role: "moderator"},
+		&domain.User{ID: 2, Email: "admin@example.com", Role: domain.RoleAdmin},
+		&domain.User{ID: 3, Email: "user@example.com", Role: domain.RoleUser},
+	)
+	roles := newTestRoleService(t, users)
+	s := NewAdminUserService(users, &stubPersonalTokenRepository{}, roles.tokenService, roles, nil)
+	admin := as(2, domain.RoleAdmin)
+
+	_, _, err := roles.tokenService.CreateSession(ctx, 3, auth.SessionMeta{})
+	require.NoError(t, err)
+
+	// 종료 시각은 미래여야 함
+	past := time.Now().Add(-time.Hour)
+	_, err = s.Suspend(admin, 3, &dto.SuspendUserRequest{Reason: "spam", Until: &past})
+	assert.Equal(t, http.StatusBadRequest, apperror.GetHTTPStatus(err))
+
+	// 자기 자신, 자신보다 권한이 많은 사용자는 정지 불가
+	_, err = s.Suspend(admin, 2, &dto.SuspendUserRequest{Reason: "spam"})
+	assertForbidden(t, err)
+	_, err = s.Suspend(as(1, "moderator"), 2, &dto.SuspendUserRequest{Reason: "spam"})
+	assertForbidden(t, err)
+
+	resp, err := s.Suspend(admin, 3, &dto.SuspendUserRequest{Reason: "spam"})
+	require.NoError(t, err)
+	assert.True(t, resp.Suspended)
+
+	// 정지와 함께 모든 세션 종료
+	sessions, err := roles.tokenService.ListSessions(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	resp, err = s.Unsuspend(admin, 3)
+	require.NoError(t, err)
+	assert.False(t, resp.Suspended)
+}
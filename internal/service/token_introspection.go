@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"strconv"
+	"strings"
+)
+
+// TokenIntrospectionService는 다른 내부 서비스가 이 API에서 발급한 토큰을 확인(RFC 7662)하고
+// 폐기(RFC 7009)할 수 있게 합니다. 클라이언트 인증은 middleware.ClientAuth에서 합니다.
+type TokenIntrospectionService struct {
+	tokenService *auth.TokenService
+	audit        *AuditService
+}
+
+func NewTokenIntrospectionService(tokenService *auth.TokenService, audit *AuditService) *TokenIntrospectionService {
+	return &TokenIntrospectionService{tokenService: tokenService, audit: audit}
+}
+
+// Introspect 토큰 확인 - 사용할 수 없는 토큰(만료, 폐기, 위조)은 에러 없이 active: false
+// hint와 다른 종류의 토큰이어도 나머지 종류로 다시 확인합니다.
+func (s *TokenIntrospectionService) Introspect(ctx context.Context, token, hint string) (*dto.IntrospectionResponse, error) {
+	for _, tokenType := range tokenTypeOrder(token, hint) {
+		var (
+			resp *dto.IntrospectionResponse
+			err  error
+		)
+		if tokenType == auth.TokenTypeHintRefresh {
+			resp, err = s.introspectRefresh(ctx, token)
+		} else {
+			resp, err = s.introspectAccess(ctx, token)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if !isInactiveToken(err) {
+			return nil, err
+		}
+	}
+	return &dto.IntrospectionResponse{Active: false}, nil
+}
+
+// Revoke 토큰 폐기
+//
+//	Access Token: 블랙리스트에 추가
+//	Refresh Token: 세션 종료 (세션에서 발급된 Access Token도 함께 무효화)
+//	이미 사용할 수 없는 토큰도 성공으로 처리합니다. Personal Access Token은 auth.ErrUnsupportedTokenType
+func (s *TokenIntrospectionService) Revoke(ctx context.Context, clientID, token, hint string) error {
+	if auth.IsPersonalToken(token) {
+		return auth.ErrUnsupportedTokenType
+	}
+
+	for _, tokenType := range tokenTypeOrder(token, hint) {
+		var (
+			userID    uint
+			sessionID string
+			err       error
+		)
+		if tokenType == auth.TokenTypeHintRefresh {
+			var session *auth.Session
+			if session, err = s.tokenService.RevokeRefreshToken(ctx, token); err == nil {
+				userID, sessionID = session.UserID, session.ID
+			}
+		} else {
+			var claims *auth.CustomClaims
+			if claims, err = s.tokenService.IntrospectAccessToken(ctx, token); err == nil {
+				userID, sessionID = claims.UserID, claims.SessionID
+				err = s.tokenService.RevokeAccessToken(ctx, token)
+			}
+		}
+
+		if err == nil {
+			s.audit.Record(ctx, AuditEntry{
+				Action:   domain.AuditTokenRevoked,
+				TargetID: &userID,
+				Metadata: map[string]any{"client_id": clientID, "token_type": tokenType, "session_id": sessionID},
+			})
+			return nil
+		}
+		if !isInactiveToken(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TokenIntrospectionService) introspectAccess(ctx context.Context, token string) (*dto.IntrospectionResponse, error) {
+	claims, err := s.tokenService.IntrospectAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.IntrospectionResponse{
+		Active:    true,
+		Username:  claims.Email,
+		TokenType: "Bearer",
+		Sub:       claims.Subject,
+		Aud:       claims.Audience,
+		Iss:       claims.Issuer,
+		Jti:       claims.ID,
+		TokenUse:  "access",
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		SessionID: claims.SessionID,
+		TwoFactor: claims.TwoFactor,
+	}
+	if claims.ExpiresAt != nil {
+		resp.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.Iat = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		resp.Nbf = claims.NotBefore.Unix()
+	}
+	if claims.IsPersonalToken() {
+		// DB에 저장된 토큰 - JWT 클레임 없음
+		resp.TokenUse = "personal"
+		resp.Scope = strings.Join(claims.Scopes, " ")
+		resp.Sub = strconv.FormatUint(uint64(claims.UserID), 10)
+	}
+	if claims.IsImpersonated() {
+		resp.Actor = &dto.IntrospectionActor{
+			Sub:    claims.Actor.Subject,
+			UserID: claims.Actor.UserID,
+			Email:  claims.Actor.Email,
+		}
+	}
+	return resp, nil
+}
+
+func (s *TokenIntrospectionService) introspectRefresh(ctx context.Context, token string) (*dto.IntrospectionResponse, error) {
+	session, claims, err := s.tokenService.IntrospectRefreshToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.IntrospectionResponse{
+		Active:    true,
+		Sub:       claims.Subject,
+		Iss:       claims.Issuer,
+		Jti:       claims.ID,
+		TokenUse:  "refresh",
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		TwoFactor: session.TwoFactor,
+	}
+	if claims.ExpiresAt != nil {
+		resp.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.Iat = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		resp.Nbf = claims.NotBefore.Unix()
+	}
+	return resp, nil
+}
+
+// tokenTypeOrder는 확인할 토큰 종류의 순서를 반환합니다. (hint를 먼저)
+// Personal Access Token은 Access Token으로만 확인합니다.
+func tokenTypeOrder(token, hint string) []string {
+	if auth.IsPersonalToken(token) {
+		return []string{auth.TokenTypeHintAccess}
+	}
+	if hint == auth.TokenTypeHintRefresh {
+		return []string{auth.TokenTypeHintRefresh, auth.TokenTypeHintAccess}
+	}
+	return []string{auth.TokenTypeHintAccess, auth.TokenTypeHintRefresh}
+}
+
+// isInactiveToken은 토큰 자체의 문제(위조, 만료, 폐기)인지 확인합니다. 아니면 서버 오류입니다.
+func isInactiveToken(err error) bool {
+	return errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issueTokens는 새 세션의 Access Token과 Refresh Token을 발급합니다.
+func issueTokens(t *testing.T, tokens *auth.TokenService, userID uint) (accessToken, refreshToken string) {
+	t.Helper()
+	refreshToken, session, err := tokens.CreateSession(context.Background(), userID, auth.SessionMeta{})
+	require.NoError(t, err)
+
+	accessToken, err = tokens.GenerateAccessToken(auth.TokenSubject{UserID: userID, Email: "user@example.com", Role: string(domain.RoleUser)}, session)
+	require.NoError(t, err)
+	return accessToken, refreshToken
+}
+
+func TestTokenIntrospection_Introspect(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	s := NewTokenIntrospectionService(tokens, nil)
+	accessToken, refreshToken := issueTokens(t, tokens, 1)
+
+	resp, err := s.Introspect(ctx, accessToken, "")
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+	assert.Equal(t, "access", resp.TokenUse)
+	assert.Equal(t, "Bearer", resp.TokenType)
+	assert.Equal(t, uint(1), resp.UserID)
+	assert.Equal(t, "user@example.com", resp.Username)
+	assert.NotZero(t, resp.Exp)
+
+	// hint가 틀려도 다른 종류로 다시 확인
+	resp, err = s.Introspect(ctx, refreshToken, auth.TokenTypeHintAccess)
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+	assert.Equal(t, "refresh", resp.TokenUse)
+	assert.Equal(t, uint(1), resp.UserID)
+
+	// 위조된 토큰은 에러 없이 active: false만
+	resp, err = s.Introspect(ctx, "not-a-token", "")
+	require.NoError(t, err)
+	assert.False(t, resp.Active)
+	assert.Empty(t, resp.TokenUse)
+}
+
+func TestTokenIntrospection_IntrospectImpersonation(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	s := NewTokenIntrospectionService(tokens, nil)
+
+	_, adminSession, err := tokens.CreateSession(ctx, 1, auth.SessionMeta{})
+	require.NoError(t, err)
+	token, err := tokens.GenerateImpersonationToken(
+		auth.TokenSubject{UserID: 2, Email: "user@example.com", Role: string(domain.RoleUser)},
+		auth.ActorClaim{Subject: "1", UserID: 1, Email: "admin@example.com"},
+		adminSession.ID, time.Minute,
+	)
+	require.NoError(t, err)
+
+	resp, err := s.Introspect(ctx, token, "")
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+	assert.Equal(t, uint(2), resp.UserID)
+	require.NotNil(t, resp.Actor)
+	assert.Equal(t, uint(1), resp.Actor.UserID)
+}
+
+func TestTokenIntrospection_RotatedRefreshTokenIsInactive(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	s := NewTokenIntrospectionService(tokens, nil)
+	_, refreshToken := issueTokens(t, tokens, 1)
+
+	session, err := tokens.ValidateRefreshToken(ctx, refreshToken)
+	require.NoError(t, err)
+	rotated, err := tokens.RotateRefreshToken(ctx, session)
+	require.NoError(t, err)
+
+	resp, err := s.Introspect(ctx, refreshToken, auth.TokenTypeHintRefresh)
+	require.NoError(t, err)
+	assert.False(t, resp.Active)
+
+	// 확인만 하므로 재사용으로 보고 세션을 폐기하지 않음
+	resp, err = s.Introspect(ctx, rotated, auth.TokenTypeHintRefresh)
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+}
+
+func TestTokenIntrospection_RevokeAccessToken(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	audit := &memoryAuditRepository{}
+	s := NewTokenIntrospectionService(tokens, NewAuditService(audit))
+	accessToken, refreshToken := issueTokens(t, tokens, 1)
+
+	require.NoError(t, s.Revoke(ctx, "billing", accessToken, ""))
+
+	resp, err := s.Introspect(ctx, accessToken, "")
+	require.NoError(t, err)
+	assert.False(t, resp.Active)
+
+	// Access Token만 폐기하고 세션은 유지
+	resp, err = s.Introspect(ctx, refreshToken, "")
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+
+	require.Len(t, audit.events, 1)
+	assert.Equal(t, domain.AuditTokenRevoked, audit.events[0].Action)
+	assert.Equal(t, uint(1), *audit.events[0].TargetID)
+	assert.JSONEq(t, `{"client_id":"billing","token_type":"access_token","session_id":"`+resp.SessionID+`"}`, audit.events[0].Metadata)
+}
+
+func TestTokenIntrospection_RevokeRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	audit := &memoryAuditRepository{}
+	s := NewTokenIntrospectionService(tokens, NewAuditService(audit))
+	accessToken, refreshToken := issueTokens(t, tokens, 1)
+
+	require.NoError(t, s.Revoke(ctx, "billing", refreshToken, auth.TokenTypeHintRefresh))
+
+	// 세션이 끝나면 세션에서 발급된 Access Token도 무효
+	for _, token := range []string{refreshToken, accessToken} {
+		resp, err := s.Introspect(ctx, token, "")
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	}
+
+	// 이미 사용할 수 없는 토큰도 성공으로 처리하고, 기록은 남기지 않음
+	assert.NoError(t, s.Revoke(ctx, "billing", refreshToken, auth.TokenTypeHintRefresh))
+	assert.NoError(t, s.Revoke(ctx, "billing", "not-a-token", ""))
+	assert.Len(t, audit.events, 1)
+}
+
+func TestTokenIntrospection_RevokePersonalToken(t *testing.T) {
+	raw, err := auth.GeneratePersonalToken()
+	require.NoError(t, err)
+
+	s := NewTokenIntrospectionService(newTestTokenService(t), nil)
+	assert.ErrorIs(t, s.Revoke(context.Background(), "billing", raw, ""), auth.ErrUnsupportedTokenType)
+}
+
+func TestTokenTypeOrder(t *testing.T) {
+	access := []string{auth.TokenTypeHintAccess, auth.TokenTypeHintRefresh}
+	refresh := []string{auth.TokenTypeHintRefresh, auth.TokenTypeHintAccess}
+
+	assert.Equal(t, access, tokenTypeOrder("jwt", ""))
+	assert.Equal(t, access, tokenTypeOrder("jwt", "unknown"))
+	assert.Equal(t, refresh, tokenTypeOrder("jwt", auth.TokenTypeHintRefresh))
+
+	raw, err := auth.GeneratePersonalToken()
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.TokenTypeHintAccess}, tokenTypeOrder(raw, auth.TokenTypeHintRefresh))
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/pkg/metrics"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotSetup         = errors.New("two-factor authentication setup not started")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication not enabled")
+	ErrTwoFactorRequired         = errors.New("two-factor authentication is required for this account")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+// 2단계 인증 기본값
+const (
+	defaultTOTPIssuer            = "GoBoard"
+	defaultTwoFactorChallengeTTL = 5 * time.Minute
+	recoveryCodeCount            = 10
+)
+
+// SetupTwoFactor는 TOTP 등록을 시작합니다.
+// 시크릿은 ConfirmTwoFactor에서 코드가 확인되기 전까지 사용되지 않습니다.
+func (s *authService) SetupTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	issuer := s.cfg.TOTPIssuer
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor는 인증 앱의 코드를 확인하고 2단계 인증을 활성화합니다.
+// 복구 코드를 새로 발급해 원문을 반환합니다. (다시 조회할 수 없음)
+func (s *authService) ConfirmTwoFactor(ctx context.Context, userID uint, code string) (*dto.TwoFactorConfirmResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotSetup
+	}
+
+	now := time.Now()
+	step, ok := auth.ValidateTOTP(user.TOTPSecret, code, now, user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recoveryRepo.ReplaceAll(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "two-factor enabled", "user_id", user.ID)
+	return &dto.TwoFactorConfirmResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor는 비밀번호와 현재 코드를 확인한 뒤 2단계 인증을 해제합니다.
+func (s *authService) DisableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorDisableRequest) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsTwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+	if s.cfg.RequireAdmin2FA {
+		admin, err := hasAdminPermission(ctx, s.permissions, string(user.Role))
+		if err != nil {
+			return err
+		}
+		if admin {
+			return ErrTwoFactorRequired
+		}
+	}
+
+	if err := s.passwordService.Compare(user.Password, req.Password); err != nil {
+		return ErrInvalidCredentials
+	}
+	if _, ok := auth.ValidateTOTP(user.TOTPSecret, req.Code, time.Now(), user.TOTPLastStep); !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := s.recoveryRepo.DeleteAll(ctx, user.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "two-factor disabled", "user_id", user.ID)
+	return nil
+}
+
+// VerifyTwoFactor는 로그인 대기 토큰과 TOTP 코드(또는 복구 코드)를 확인하고 토큰을 발급합니다.
+func (s *authService) VerifyTwoFactor(ctx context.Context, req *dto.TwoFactorVerifyRequest, meta auth.SessionMeta) (*dto.LoginResponse, error) {
+	// 1. 대기 토큰 검증
+	claims, err := s.tokenService.ValidateChallengeToken(ctx, req.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+	if !user.IsTwoFactorEnabled() {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	// 잠금 확인 - 코드 무차별 대입도 로그인 실패로 취급
+	if err := s.loginGuard.Check(ctx, user.Email, meta.IP); err != nil {
+		if errors.Is(err, auth.ErrLoginLocked) {
+			metrics.UserLogins.WithLabelValues("locked").Inc()
+		}
+		return nil, err
+	}
+
+	// 2. 코드 확인
+	method := domain.LoginMethodTOTP
+	if req.Code != "" {
+		step, ok := auth.ValidateTOTP(user.TOTPSecret, req.Code, time.Now(), user.TOTPLastStep)
+		if !ok {
+			slog.WarnContext(ctx, "two-factor failed", "user_id", user.ID, "reason", "invalid_code")
+			return nil, s.loginFailed(ctx, user.Email, user, domain.LoginMethodTOTP, meta, "invalid_two_factor_code", ErrInvalidTwoFactorCode)
+		}
+		user.TOTPLastStep = step
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.recoveryRepo.Consume(ctx, user.ID, hashUserToken(normalizeRecoveryCode(req.RecoveryCode))); err != nil {
+			if errors.Is(err, repository.ErrRecoveryCodeInvalid) {
+				slog.WarnContext(ctx, "two-factor failed", "user_id", user.ID, "reason", "invalid_recovery_code")
+				return nil, s.loginFailed(ctx, user.Email, user, domain.LoginMethodRecoveryCode, meta, "invalid_recovery_code", ErrInvalidTwoFactorCode)
+			}
+			return nil, err
+		}
+		slog.WarnContext(ctx, "recovery code used", "user_id", user.ID)
+		method = domain.LoginMethodRecoveryCode
+	}
+
+	// 3. 대기 토큰 재사용 방지
+	if err := s.tokenService.ConsumeChallengeToken(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	meta.DeviceName = claims.DeviceName
+	meta.TwoFactor = true
+	return s.completeLogin(ctx, user, method, meta)
+}
+
+// twoFactorChallenge는 비밀번호가 확인된 2단계 인증 사용자에게 대기 토큰을 발급합니다.
+func (s *authService) twoFactorChallenge(user *domain.User, deviceName string) (*dto.LoginResponse, error) {
+	// 정지된 계정은 코드 입력 단계로 넘어가지 않음
+	if err := checkSuspended(user); err != nil {
+		return nil, err
+	}
+
+	ttl := s.cfg.TwoFactorChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultTwoFactorChallengeTTL
+	}
+
+	challenge, err := s.tokenService.GenerateChallengeToken(user.ID, deviceName, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+	}, nil
+}
+
+// generateRecoveryCodes는 복구 코드 원문(xxxxx-xxxxx)과 저장할 해시를 생성합니다.
+func generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7) // 56비트
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(b))[:10]
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashUserToken(raw)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode는 사용자가 입력한 복구 코드의 하이픈/공백/대소문자를 정리합니다.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -1,16 +1,141 @@
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm-test/internal/auth"
+	"gorm-test/internal/config"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+	"gorm-test/internal/repository"
+	"gorm-test/pkg/apperror"
+	"log/slog"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
 
+const (
+	defaultAccountDeletionGrace = 30 * 24 * time.Hour
+	accountPurgeBatchSize       = 100
+
+	// 비밀번호 없이 탈퇴할 수 있는 최근 로그인 기준 (외부 IdP로만 로그인하는 사용자는 비밀번호를 모름)
+	recentLoginWindow = 5 * time.Minute
+)
+
+// UserService는 사용자 본인의 계정 관리(프로필, 비밀번호 변경, 탈퇴)를 담당합니다.
+type UserService interface {
+	GetProfile(ctx context.Context, userID uint) (*dto.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID uint, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error)
+	ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error
+
+	// 탈퇴 - 유예 기간 동안은 취소할 수 있고, 지나면 PurgeDeletedAccounts가 익명화합니다.
+	// 비밀번호가 없으면 sessionID의 세션이 최근 로그인(IdP 재인증 포함)으로 만들어졌는지 확인합니다.
+	DeleteAccount(ctx context.Context, userID uint, sessionID, password string) (*dto.AccountDeletionResponse, error)
+	CancelDeletion(ctx context.Context, userID uint) (*dto.ProfileResponse, error)
+	PurgeDeletedAccounts(ctx context.Context) (int, error)
+
+	// 로그인 기록 (최신순)
+	ListLogins(ctx context.Context, userID uint, pagination *dto.Pagination) ([]*dto.LoginEventResponse, *dto.Meta, error)
+}
+
+type userService struct {
+	userRepo          repository.UserRepository
+	personalTokenRepo repository.PersonalAccessTokenRepository
+	loginEventRepo    repository.LoginEventRepository
+	passwordService   *auth.PasswordService
+	tokenService      *auth.TokenService
+	permissions       auth.PermissionChecker
+	loginGuard        *auth.LoginGuard
+	audit             *AuditService
+	cfg               config.AuthConfig
+}
+
+func NewUserService(
+	userRepo repository.UserRepository,
+	personalTokenRepo repository.PersonalAccessTokenRepository,
+	loginEventRepo repository.LoginEventRepository,
+	passwordService *auth.PasswordService,
+	tokenService *auth.TokenService,
+	permissions auth.PermissionChecker,
+	loginGuard *auth.LoginGuard,
+	audit *AuditService,
+	cfg config.AuthConfig,
+) UserService {
+	return &userService{
+		userRepo:          userRepo,
+		personalTokenRepo: personalTokenRepo,
+		loginEventRepo:    loginEventRepo,
+		passwordService:   passwordService,
+		tokenService:      tokenService,
+		permissions:       permissions,
+		loginGuard:        loginGuard,
+		audit:             audit,
+		cfg:               cfg,
+	}
+}
+
+func (s *userService) GetProfile(ctx context.Context, userID uint) (*dto.ProfileResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return profileResponse(user), nil
+}
+
+func (s *userService) UpdateProfile(ctx context.Context, userID uint, req *dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Username != nil {
+		user.Username = strings.TrimSpace(*req.Username)
+	}
+	if req.Bio != nil {
+		user.Bio = strings.TrimSpace(*req.Bio)
+	}
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" && !isHTTPURL(avatarURL) {
+			return nil, apperror.ValidationError(map[string]string{
+				"avatar_url": "http 또는 https URL이어야 합니다",
+			})
+		}
+		user.AvatarURL = avatarURL
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return profileResponse(user), nil
+}
+
+// ChangePassword 비밀번호 변경 - 현재 비밀번호를 확인하고, 변경 후 모든 기기에서 로그아웃합니다.
 func (s *userService) ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error {
-	user, err := s.userRepo.FindByID(ctx, userID)
+	user, err := s.findUser(ctx, userID)
 	if err != nil {
 		return err
 	}
 
 	// 현재 비밀번호 확인
-	if err := s.passwordService.Compare(user.Password, currentPassword); err != nil {
-		return ErrInvalidCredentials
+	if err := s.verifyPassword(ctx, user, currentPassword); err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return apperror.ValidationError(map[string]string{
+				"current_password": "현재 비밀번호가 올바르지 않습니다",
+			})
+		}
+		return err
+	}
+	if currentPassword == newPassword {
+		return apperror.ValidationError(map[string]string{
+			"new_password": "현재 비밀번호와 다른 비밀번호를 사용해야 합니다",
+		})
+	}
+	if err := validatePassword(s.passwordService, "new_password", newPassword, user.Email, user.Username); err != nil {
+		return err
 	}
 
 	// 새 비밀번호 해싱
@@ -25,9 +150,242 @@ func (s *userService) ChangePassword(ctx context.Context, userID uint, currentPa
 		return err
 	}
 
-	// 모든 토큰 무효화
-	_ = s.tokenService.RevokeRefreshToken(ctx, userID)
+	// 모든 Refresh Token 무효화
 	// Access Token은 짧은 만료 시간이므로 블랙리스트에 추가하지 않아도 됨
+	if err := s.tokenService.RevokeAllSessions(ctx, userID); err != nil {
+		return err
+	}
 
+	slog.InfoContext(ctx, "password changed", "user_id", user.ID)
+	s.audit.Record(ctx, AuditEntry{Action: domain.AuditPasswordChanged, TargetID: &user.ID})
 	return nil
 }
+
+// DeleteAccount 탈퇴 요청 - 유예 기간 후 삭제되도록 예약하고 모든 세션과 토큰을 폐기합니다.
+func (s *userService) DeleteAccount(ctx context.Context, userID uint, sessionID, password string) (*dto.AccountDeletionResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if password == "" {
+		if !s.isRecentLogin(ctx, userID, sessionID) {
+			return nil, apperror.ValidationError(map[string]string{
+				"password": "비밀번호를 입력하거나, 다시 로그인한 뒤 5분 안에 요청하세요",
+			})
+		}
+	} else if err := s.verifyPassword(ctx, user, password); err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return nil, apperror.ValidationError(map[string]string{
+				"password": "비밀번호가 올바르지 않습니다",
+			})
+		}
+		return nil, err
+	}
+	// 관리자 권한이 있는 역할은 탈퇴 불가 (역할 이름이 아니라 권한으로 판단)
+	admin, err := hasAdminPermission(ctx, s.permissions, string(user.Role))
+	if err != nil {
+		return nil, err
+	}
+	if admin {
+		return nil, apperror.Forbidden("관리자는 탈퇴할 수 없습니다. 다른 관리자에게 역할 변경을 요청하세요")
+	}
+
+	if user.DeletionDueAt == nil {
+		grace := s.cfg.AccountDeletionGrace
+		if grace <= 0 {
+			grace = defaultAccountDeletionGrace
+		}
+		dueAt := time.Now().Add(grace)
+		user.DeletionDueAt = &dueAt
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.tokenService.RevokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	if err := s.personalTokenRepo.DeleteAllByUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "account deletion scheduled", "user_id", user.ID, "due_at", user.DeletionDueAt)
+	s.audit.Record(ctx, AuditEntry{
+		Action:   domain.AuditDeletionScheduled,
+		TargetID: &user.ID,
+		Metadata: map[string]any{"due_at": user.DeletionDueAt},
+	})
+	return &dto.AccountDeletionResponse{DeletionDueAt: *user.DeletionDueAt}, nil
+}
+
+// CancelDeletion 탈퇴 취소 (유예 기간 중 다시 로그인한 경우)
+func (s *userService) CancelDeletion(ctx context.Context, userID uint) (*dto.ProfileResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsDeletionScheduled() {
+		return nil, apperror.Conflict("탈퇴 요청이 없습니다")
+	}
+
+	user.DeletionDueAt = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "account deletion canceled", "user_id", user.ID)
+	return profileResponse(user), nil
+}
+
+// PurgeDeletedAccounts 유예 기간이 끝난 계정을 익명화합니다. 처리한 계정 수를 반환합니다.
+// 한 계정이 실패해도 나머지는 계속 처리합니다.
+func (s *userService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindDeletionDue(ctx, time.Now(), accountPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		if err := s.userRepo.Anonymize(ctx, user.ID); err != nil {
+			slog.ErrorContext(ctx, "account purge failed", "user_id", user.ID, "error", err)
+			continue
+		}
+		// 유예 기간 중 다시 로그인해서 만든 세션이 남아 있을 수 있음
+		if err := s.tokenService.RevokeAllSessions(ctx, user.ID); err != nil {
+			slog.ErrorContext(ctx, "session revoke failed", "user_id", user.ID, "error", err)
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// RunAccountPurger는 interval마다 PurgeDeletedAccounts를 실행합니다. ctx가 끝나면 종료합니다.
+func RunAccountPurger(ctx context.Context, users UserService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := users.PurgeDeletedAccounts(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "account purge failed", "error", err)
+				continue
+			}
+			if purged > 0 {
+				slog.InfoContext(ctx, "deleted accounts purged", "count", purged)
+			}
+		}
+	}
+}
+
+func (s *userService) ListLogins(ctx context.Context, userID uint, pagination *dto.Pagination) ([]*dto.LoginEventResponse, *dto.Meta, error) {
+	events, total, err := s.loginEventRepo.ListByUser(ctx, userID, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := make([]*dto.LoginEventResponse, len(events))
+	for i, event := range events {
+		list[i] = &dto.LoginEventResponse{
+			ID:            event.ID,
+			Method:        string(event.Method),
+			Success:       event.Success,
+			FailureReason: event.FailureReason,
+			IP:            event.IP,
+			UserAgent:     event.UserAgent,
+			DeviceName:    event.DeviceName,
+			NewDevice:     event.NewDevice,
+			CreatedAt:     event.CreatedAt,
+		}
+	}
+
+	meta := &dto.Meta{
+		Page:       pagination.Page,
+		Size:       pagination.Size,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Size))),
+	}
+	return list, meta, nil
+}
+
+// verifyPassword는 본인 확인용 비밀번호를 검사합니다.
+// 실패는 로그인 실패와 같이 계정 잠금 횟수에 포함합니다. (탈취한 세션으로 비밀번호를 추측하지 못하도록)
+// 틀리면 ErrInvalidCredentials, 잠겨 있으면 Locked 에러를 반환합니다.
+func (s *userService) verifyPassword(ctx context.Context, user *domain.User, password string) error {
+	if err := s.loginGuard.Check(ctx, user.Email, ""); err != nil {
+		return lockedError(err)
+	}
+
+	if err := s.passwordService.Compare(user.Password, password); err != nil {
+		slog.WarnContext(ctx, "password confirmation failed", "user_id", user.ID)
+		if err := s.loginGuard.RecordFailure(ctx, user.Email, ""); err != nil {
+			return lockedError(err)
+		}
+		return ErrInvalidCredentials
+	}
+
+	return s.loginGuard.RecordSuccess(ctx, user.Email)
+}
+
+// isRecentLogin은 현재 세션이 recentLoginWindow 안에 로그인해서 만든 세션인지 확인합니다.
+// Personal Access Token처럼 세션이 없는 요청은 false입니다.
+func (s *userService) isRecentLogin(ctx context.Context, userID uint, sessionID string) bool {
+	session, err := s.tokenService.GetSession(ctx, userID, sessionID)
+	if err != nil {
+		if !errors.Is(err, auth.ErrSessionNotFound) {
+			slog.ErrorContext(ctx, "session lookup failed", "user_id", userID, "error", err)
+		}
+		return false
+	}
+	return time.Since(session.CreatedAt) <= recentLoginWindow
+}
+
+// lockedError는 계정 잠금 에러를 423 응답용 에러로 바꿉니다.
+func lockedError(err error) error {
+	var lockedErr *auth.LockedError
+	if !errors.As(err, &lockedErr) {
+		return err
+	}
+	return apperror.Locked("비밀번호 확인 실패가 반복되어 계정이 일시적으로 잠겼습니다").
+		WithDetail(fmt.Sprintf("%d초 후 다시 시도해주세요", int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+}
+
+func (s *userService) findUser(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NotFound("사용자")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// isHTTPURL은 http/https 절대 URL인지 확인합니다. (javascript: 등 차단)
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func profileResponse(user *domain.User) *dto.ProfileResponse {
+	return &dto.ProfileResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		Username:         user.Username,
+		Bio:              user.Bio,
+		AvatarURL:        user.AvatarURL,
+		Role:             string(user.Role),
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
+		DeletionDueAt:    user.DeletionDueAt,
+		CreatedAt:        user.CreatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"gorm-test/internal/auth"
+	"gorm-test/internal/config"
+	"gorm-test/internal/domain"
+	"gorm-test/internal/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccount_AdminPermissionRolesCannotDelete(t *testing.T) {
+	ctx := context.Background()
+	users := newMemoryUserRepository(
+		&domain.User{ID: 1, Email: "deleter@example.com", Role: "deleter"},
+		&domain.User{ID: 2, Email: "roles@example.com", Role: "role-manager"},
+		&domain.User{ID: 3, Email: "user@example.com", Role: domain.RoleUser},
+	)
+	roles := newTestRoleService(t, users)
+	admin := as(9, domain.RoleAdmin)
+
+	// 이름은 admin이 아니지만 관리자 권한이 있는 역할
+	_, err := roles.Create(admin, &dto.CreateRoleRequest{Name: "deleter", Permissions: []string{string(domain.PermUserDelete)}})
+	require.NoError(t, err)
+	_, err = roles.Create(admin, &dto.CreateRoleRequest{Name: "role-manager", Permissions: []string{string(domain.PermRoleManage)}})
+	require.NoError(t, err)
+
+	s := NewUserService(users, &stubPersonalTokenRepository{}, nil, nil, roles.tokenService, roles, nil, nil, config.AuthConfig{})
+
+	// 방금 로그인한 세션이면 비밀번호 없이 요청 가능
+	deleteAccount := func(userID uint) (*dto.AccountDeletionResponse, error) {
+		_, session, err := roles.tokenService.CreateSession(ctx, userID, auth.SessionMeta{})
+		require.NoError(t, err)
+		return s.DeleteAccount(ctx, userID, session.ID, "")
+	}
+
+	for _, userID := range []uint{1, 2} {
+		_, err := deleteAccount(userID)
+		assertForbidden(t, err)
+
+		user, err := users.FindByID(ctx, userID)
+		require.NoError(t, err)
+		assert.Nil(t, user.DeletionDueAt, userID)
+	}
+
+	resp, err := deleteAccount(3)
+	require.NoError(t, err)
+	assert.False(t, resp.DeletionDueAt.IsZero())
+
+	sessions, err := roles.tokenService.ListSessions(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
@@ -24,32 +24,31 @@ var (
 )
 
 // AuthMiddleware는 JWT 인증 미들웨어입니다.
+// Authorization 헤더가 없으면 쿠키 세션의 Access Token 쿠키를 사용합니다.
 func AuthMiddleware(tokenService *auth.TokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 1. Authorization 헤더 추출
-		authHeader := c.GetHeader(AuthorizationHeader)
-		if authHeader == "" {
+		// 1. 토큰 추출 (Authorization 헤더 또는 쿠키)
+		tokenString, fromCookie, err := ExtractToken(c)
+		if err != nil {
+			message := "인증 토큰이 필요합니다"
+			if errors.Is(err, ErrInvalidFormat) {
+				message = "잘못된 인증 형식입니다"
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "인증 토큰이 필요합니다",
+				"error": message,
 			})
 			return
 		}
 
-		// 2. Bearer 토큰 형식 검증
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != AuthorizationType {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "잘못된 인증 형식입니다",
-			})
+		// 2. 쿠키로 인증하는 요청은 CSRF 토큰 확인 (브라우저가 자동으로 쿠키를 보내므로)
+		if fromCookie && !ValidCSRF(c) {
+			abortCSRF(c)
 			return
 		}
 
-		tokenString := parts[1]
-
-		// 3. 토큰 검증
-		claims, err := tokenService.ValidateToken(tokenString)
-		if err != nil {
-			handleTokenError(c, err)
+		// 3. 토큰 검증 (Personal Access Token 또는 JWT)
+		claims, ok := authenticate(c, tokenService, tokenString)
+		if !ok {
 			return
 		}
 
@@ -60,11 +59,65 @@ func AuthMiddleware(tokenService *auth.TokenService) gin.HandlerFunc {
 		ctx := SetUserToContext(c.Request.Context(), claims)
 		c.Request = c.Request.WithContext(ctx)
 
-		// 5. 다음 핸들러로 진행
+		// 5. 다음 핸들러로 진행 (대리 로그인이면 응답에 표시하고 요청 기록)
+		if claims.IsImpersonated() {
+			serveImpersonated(c, claims)
+			return
+		}
 		c.Next()
 	}
 }
 
+// ExtractToken은 요청에서 토큰을 꺼냅니다.
+// Authorization 헤더를 먼저 보고, 없으면 Access Token 쿠키를 사용합니다. (fromCookie = true)
+func ExtractToken(c *gin.Context) (token string, fromCookie bool, err error) {
+	if authHeader := c.GetHeader(AuthorizationHeader); authHeader != "" {
+		// Bearer 토큰 형식 검증
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) != 2 || parts[0] != AuthorizationType {
+			return "", false, ErrInvalidFormat
+		}
+		return parts[1], false, nil
+	}
+
+	if cookie, err := c.Cookie(AccessTokenCookie); err == nil && cookie != "" {
+		return cookie, true, nil
+	}
+	return "", false, ErrMissingToken
+}
+
+// authenticate는 토큰을 검증하고 사용자 정보를 반환합니다.
+// 실패하면 요청을 중단하고 false를 반환합니다.
+func authenticate(c *gin.Context, tokenService *auth.TokenService, tokenString string) (*auth.CustomClaims, bool) {
+	if auth.IsPersonalToken(tokenString) {
+		claims, err := tokenService.ValidatePersonalToken(c.Request.Context(), tokenString)
+		if err != nil {
+			if !errors.Is(err, auth.ErrInvalidToken) && !errors.Is(err, auth.ErrExpiredToken) {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "토큰 검증 중 오류가 발생했습니다",
+				})
+				return nil, false
+			}
+			handleTokenError(c, err)
+			return nil, false
+		}
+		return claims, true
+	}
+
+	claims, err := tokenService.ValidateToken(tokenString)
+	if err != nil {
+		handleTokenError(c, err)
+		return nil, false
+	}
+
+	// 블랙리스트 확인 (로그아웃/세션 종료된 토큰)
+	if !checkRevoked(c, tokenService, claims, tokenString) {
+		return nil, false
+	}
+
+	return claims, true
+}
+
 func handleTokenError(c *gin.Context, err error) {
 	if errors.Is(err, auth.ErrExpiredToken) {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -79,6 +132,27 @@ func handleTokenError(c *gin.Context, err error) {
 	})
 }
 
+// checkRevoked는 무효화된 토큰이면 요청을 중단하고 false를 반환합니다.
+func checkRevoked(c *gin.Context, tokenService *auth.TokenService, claims *auth.CustomClaims, tokenString string) bool {
+	revoked, err := tokenService.IsRevoked(c.Request.Context(), claims, tokenString)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 검증 중 오류가 발생했습니다",
+		})
+		return false
+	}
+
+	if revoked {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "토큰이 무효화되었습니다",
+			"code":  "TOKEN_REVOKED",
+		})
+		return false
+	}
+
+	return true
+}
+
 // GetCurrentUser는 컨텍스트에서 현재 사용자 정보를 추출합니다.
 func GetCurrentUser(c *gin.Context) (*auth.CustomClaims, bool) {
 	value, exists := c.Get(ContextUserKey)
@@ -104,26 +178,39 @@ func MustGetCurrentUser(c *gin.Context) *auth.CustomClaims {
 }
 
 // OptionalAuthMiddleware는 선택적 인증 미들웨어입니다.
-// 토큰이 있으면 검증하고, 없으면 그냥 통과합니다.
-func OptionalAuthMiddleware(tokenService *auth.TokenService, tokenStore auth.TokenStore) gin.HandlerFunc {
+// 토큰이 있으면 검증하고, 없으면 그냥 통과합니다. (헤더 또는 쿠키)
+func OptionalAuthMiddleware(tokenService *auth.TokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader(AuthorizationHeader)
+		tokenString, fromCookie, err := ExtractToken(c)
 
-		// 토큰이 없으면 그냥 통과
-		if authHeader == "" {
+		// 토큰이 없거나 형식이 잘못되어도 그냥 통과 (에러 반환 안 함)
+		if err != nil {
 			c.Next()
 			return
 		}
 
-		// 토큰이 있으면 검증 시도
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != AuthorizationType {
-			// 형식이 잘못되어도 그냥 통과 (에러 반환 안 함)
+		// CSRF 토큰이 없는 쿠키 요청은 비로그인으로 처리
+		if fromCookie && !ValidCSRF(c) {
 			c.Next()
 			return
 		}
 
-		tokenString := parts[1]
+		// Personal Access Token
+		if auth.IsPersonalToken(tokenString) {
+			claims, err := tokenService.ValidatePersonalToken(c.Request.Context(), tokenString)
+			if err != nil {
+				if errors.Is(err, auth.ErrExpiredToken) {
+					c.Header("X-Token-Expired", "true")
+				}
+				c.Next()
+				return
+			}
+
+			c.Set(ContextUserKey, claims)
+			c.Request = c.Request.WithContext(SetUserToContext(c.Request.Context(), claims))
+			c.Next()
+			return
+		}
 
 		claims, err := tokenService.ValidateToken(tokenString)
 		if err != nil {
@@ -137,27 +224,8 @@ func OptionalAuthMiddleware(tokenService *auth.TokenService, tokenStore auth.Tok
 		}
 		
 		// 블랙리스트 확인
-		if tokenStore != nil {
-			tokenID := claims.RegisteredClaims.ID
-			if tokenID == "" {
-				tokenID = hashToken(tokenString)
-			}
-
-			blacklisted, err := tokenStore.IsBlacklisted(c.Request.Context(), tokenID)
-			if err != nil {
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"error": "토큰 검증 중 오류가 발생했습니다",
-				})
-				return
-			}
-
-			if blacklisted {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-					"error": "토큰이 무효화되었습니다",
-					"code":  "TOKEN_REVOKED",
-				})
-				return
-			}
+		if !checkRevoked(c, tokenService, claims, tokenString) {
+			return
 		}
 
 		// 유효한 토큰이면 컨텍스트에 저장
@@ -165,6 +233,10 @@ func OptionalAuthMiddleware(tokenService *auth.TokenService, tokenStore auth.Tok
 		ctx := SetUserToContext(c.Request.Context(), claims)
 		c.Request = c.Request.WithContext(ctx)
 
+		if claims.IsImpersonated() {
+			serveImpersonated(c, claims)
+			return
+		}
 		c.Next()
 	}
 }
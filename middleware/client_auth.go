@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"gorm-test/internal/auth"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextClientKey는 인증된 client_id를 저장하는 컨텍스트 키입니다.
+const ContextClientKey = "oauthClientID"
+
+// ClientAuth는 OAuth 클라이언트 인증 미들웨어입니다. (RFC 6749 2.3.1)
+// HTTP Basic(client_secret_basic) 또는 폼 파라미터(client_secret_post)의 client_id/client_secret을 확인합니다.
+// 실패하면 401 invalid_client를 반환합니다.
+func ClientAuth(clients *auth.ClientCredentials) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, clientSecret, ok := c.Request.BasicAuth()
+		if ok {
+			// Basic 인증의 값은 form-urlencoded로 인코딩됨
+			clientID, _ = url.QueryUnescape(clientID)
+			clientSecret, _ = url.QueryUnescape(clientSecret)
+		} else {
+			clientID = c.PostForm("client_id")
+			clientSecret = c.PostForm("client_secret")
+		}
+
+		if clientID == "" || !clients.Authenticate(clientID, clientSecret) {
+			slog.WarnContext(c.Request.Context(), "oauth client authentication failed", "client_id", clientID, "ip", c.ClientIP())
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_client",
+				"error_description": "클라이언트 인증에 실패했습니다",
+			})
+			return
+		}
+
+		c.Set(ContextClientKey, clientID)
+		c.Next()
+	}
+}
+
+// GetClientID는 ClientAuth로 인증된 client_id를 반환합니다.
+func GetClientID(c *gin.Context) string {
+	return c.GetString(ContextClientKey)
+}
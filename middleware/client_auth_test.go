@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"gorm-test/internal/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientAuth(t *testing.T) {
+	clients := auth.NewClientCredentials(map[string]string{
+		"billing": "s3cret&key",
+		"empty":   "", // 비밀값이 없으면 등록하지 않음
+	})
+
+	r := gin.New()
+	r.POST("/introspect", ClientAuth(clients), func(c *gin.Context) {
+		c.String(http.StatusOK, GetClientID(c))
+	})
+
+	cases := []struct {
+		name     string
+		basic    bool // false면 폼 파라미터로 보냄
+		clientID string
+		secret   string
+		want     int
+	}{
+		{"client_secret_basic", true, "billing", "s3cret&key", http.StatusOK},
+		{"client_secret_post", false, "billing", "s3cret&key", http.StatusOK},
+		{"비밀값 불일치", true, "billing", "wrong", http.StatusUnauthorized},
+		{"없는 클라이언트", false, "unknown", "s3cret&key", http.StatusUnauthorized},
+		{"비밀값 없는 클라이언트", false, "empty", "", http.StatusUnauthorized},
+		{"인증 정보 없음", false, "", "", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			form := url.Values{"token": {"t"}}
+			if !tc.basic && tc.clientID != "" {
+				form.Set("client_id", tc.clientID)
+				form.Set("client_secret", tc.secret)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/introspect", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.basic {
+				// Basic 인증의 값은 form-urlencoded로 인코딩해서 보냄
+				req.SetBasicAuth(url.QueryEscape(tc.clientID), url.QueryEscape(tc.secret))
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code)
+			if tc.want == http.StatusOK {
+				assert.Equal(t, "billing", w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), "invalid_client")
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -9,7 +9,17 @@ import (
 
 type contextKey string
 
-const userContextKey contextKey = "user"
+const (
+	userContextKey        contextKey = "user"
+	requestInfoContextKey contextKey = "request_info"
+)
+
+// RequestInfo는 감사 로그 등 서비스에서 기록할 요청 정보입니다.
+type RequestInfo struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
 
 // SetUserToContext는 사용자 정보를 context.Context에 저장합니다.
 func SetUserToContext(ctx context.Context, claims *auth.CustomClaims) context.Context {
@@ -22,6 +32,17 @@ func GetUserFromContext(ctx context.Context) (*auth.CustomClaims, bool) {
 	return claims, ok
 }
 
+// SetRequestInfoToContext는 요청 정보를 context.Context에 저장합니다.
+func SetRequestInfoToContext(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoContextKey, info)
+}
+
+// GetRequestInfoFromContext는 context.Context에서 요청 정보를 추출합니다. 없으면 빈 값입니다.
+func GetRequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoContextKey).(RequestInfo)
+	return info
+}
+
 // internal/middleware/context.go (추가)
 
 // IsAuthenticated는 현재 요청이 인증되었는지 확인합니다.
@@ -9,24 +9,38 @@ import (
 
 type CORSConfig struct {
 	AllowedOrigins []string
+	DevOrigins     []string // 개발 환경에서 허용할 Origin (비우면 DefaultDevOrigins)
 	Debug          bool
 }
 
+// DefaultDevOrigins 개발 환경 기본 허용 Origin (로컬 프론트엔드 개발 서버)
+var DefaultDevOrigins = []string{
+	"http://localhost:3000",
+	"http://127.0.0.1:3000",
+}
+
 func CORS(cfg CORSConfig) gin.HandlerFunc {
 	corsConfig := cors.Config{
 		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, // 허용할 HTTP 메서드
 		AllowHeaders: []string{
 			"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", // 허용할 헤더
+			CSRFHeader, SessionModeHeader, // 쿠키 세션
+		},
+		ExposeHeaders: []string{
+			"Content-Length", "X-Request-ID", "X-Response-Time", // 응답에서 노출할 헤더
+			ImpersonatedByHeader, // 대리 로그인 표시
 		},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-Response-Time"}, // 응답에서 노출할 헤더
-		AllowCredentials: true,                                                          // 쿠키 허용 여부
-		MaxAge:           12 * time.Hour,                                                // Preflight 결과 캐시 시간
+		AllowCredentials: true,           // 쿠키 허용 여부
+		MaxAge:           12 * time.Hour, // Preflight 결과 캐시 시간
 	}
 
 	if cfg.Debug {
-		// 개발 환경: 모든 Origin 허용
-		corsConfig.AllowAllOrigins = true
-		corsConfig.AllowCredentials = false // AllowAllOrigins와 함께 사용 불가
+		// 개발 환경: 로컬 개발 서버만 허용
+		// 쿠키 세션(AllowCredentials)을 쓰므로 모든 Origin을 허용하면 다른 사이트가 로그인된 사용자로 요청할 수 있음
+		corsConfig.AllowOrigins = cfg.DevOrigins
+		if len(corsConfig.AllowOrigins) == 0 {
+			corsConfig.AllowOrigins = DefaultDevOrigins
+		}
 	} else {
 		// 프로덕션: 지정된 Origin만 허용
 		corsConfig.AllowOrigins = cfg.AllowedOrigins // 허용할 도메인 목록입니다. 와일드카드를 사용할 수 있지만, AllowCredentials가 true면 *를 사용할 수 없습니다.
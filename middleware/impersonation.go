@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"gorm-test/internal/auth"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonatedByHeader는 대리 로그인 토큰으로 한 요청의 응답에 관리자 ID를 표시하는 헤더입니다.
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// serveImpersonated는 대리 로그인 요청을 처리합니다.
+// 응답에 대리 로그인임을 표시하고, 요청마다 관리자와 대상 사용자를 함께 기록합니다.
+func serveImpersonated(c *gin.Context, claims *auth.CustomClaims) {
+	c.Header(ImpersonatedByHeader, strconv.FormatUint(uint64(claims.Actor.UserID), 10))
+
+	start := time.Now()
+	c.Next()
+
+	slog.InfoContext(c.Request.Context(), "impersonated request",
+		"actor_id", claims.Actor.UserID,
+		"actor_email", claims.Actor.Email,
+		"user_id", claims.UserID,
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"status", c.Writer.Status(),
+		"latency", time.Since(start),
+	)
+}
+
+// DenyImpersonation은 대리 로그인 토큰을 거부하는 미들웨어입니다.
+// 비밀번호 변경, 토큰 발급처럼 본인만 해야 하는 작업에 사용합니다. AuthMiddleware 뒤에 등록해야 합니다.
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		if claims.IsImpersonated() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "대리 로그인 중에는 사용할 수 없습니다",
+				"code":  "IMPERSONATION_FORBIDDEN",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
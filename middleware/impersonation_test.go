@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gorm-test/internal/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// impersonationRouter는 /me(누구나)와 /me/password(본인만) 라우트를 가진 라우터입니다.
+func impersonationRouter(tokens *auth.TokenService) *gin.Engine {
+	r := gin.New()
+	me := r.Group("/me", AuthMiddleware(tokens))
+	me.GET("", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	me.POST("/password", DenyImpersonation(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return r
+}
+
+func serveBearer(r http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(AuthorizationHeader, AuthorizationType+" "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestDenyImpersonation(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	r := impersonationRouter(tokens)
+
+	_, adminSession, err := tokens.CreateSession(ctx, 1, auth.SessionMeta{})
+	require.NoError(t, err)
+	impersonation, err := tokens.GenerateImpersonationToken(
+		auth.TokenSubject{UserID: 2, Email: "user@example.com", Role: "user"},
+		auth.ActorClaim{Subject: "1", UserID: 1, Email: "admin@example.com"},
+		adminSession.ID, time.Minute,
+	)
+	require.NoError(t, err)
+
+	// 조회는 가능하고, 응답에 대리 로그인한 관리자를 표시
+	w := serveBearer(r, http.MethodGet, "/me", impersonation)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "1", w.Header().Get(ImpersonatedByHeader))
+
+	// 본인만 할 수 있는 작업은 거부
+	w = serveBearer(r, http.MethodPost, "/me/password", impersonation)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "IMPERSONATION_FORBIDDEN")
+
+	// 일반 토큰은 통과하고 표시 헤더 없음
+	w = serveBearer(r, http.MethodPost, "/me/password", issueAccessToken(t, tokens, 2, "user"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get(ImpersonatedByHeader))
+}
+
+func TestImpersonationToken_EndsWithAdminSession(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+	r := impersonationRouter(tokens)
+
+	_, adminSession, err := tokens.CreateSession(ctx, 1, auth.SessionMeta{})
+	require.NoError(t, err)
+	impersonation, err := tokens.GenerateImpersonationToken(
+		auth.TokenSubject{UserID: 2, Email: "user@example.com", Role: "user"},
+		auth.ActorClaim{Subject: "1", UserID: 1},
+		adminSession.ID, time.Minute,
+	)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, serveBearer(r, http.MethodGet, "/me", impersonation).Code)
+
+	// 관리자가 로그아웃하면 대리 로그인 토큰도 무효
+	require.NoError(t, tokens.RevokeSession(ctx, 1, adminSession.ID))
+	w := serveBearer(r, http.MethodGet, "/me", impersonation)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "TOKEN_REVOKED")
+}
+
+func TestDenyImpersonation_RequiresAuthentication(t *testing.T) {
+	r := gin.New()
+	r.GET("/", DenyImpersonation(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
 	"gorm-test/internal/repository"
 	"net/http"
 	"strconv"
@@ -8,10 +10,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// RequirePostOwner는 게시글 소유자만 허용합니다.
+// RequirePostOwner는 게시글 소유자 또는 permission이 있는 역할만 허용합니다.
 //
 //	복잡한 권한 검사는 서비스 레이어에서 하는 것이 더 깔끔할 수 있습니다.
-func RequirePostOwner(postRepo repository.PostRepository) gin.HandlerFunc {
+func RequirePostOwner(postRepo repository.PostRepository, checker auth.PermissionChecker, permission domain.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims, ok := GetCurrentUser(c)
 		if !ok {
@@ -21,8 +23,15 @@ func RequirePostOwner(postRepo repository.PostRepository) gin.HandlerFunc {
 			return
 		}
 
-		// 관리자는 통과
-		if claims.Role == "admin" {
+		// 다른 사용자의 게시글 권한이 있으면 통과
+		allowed, err := checker.HasPermission(c.Request.Context(), claims.Role, permission)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "서버 오류가 발생했습니다",
+			})
+			return
+		}
+		if allowed {
 			c.Next()
 			return
 		}
@@ -37,7 +46,7 @@ func RequirePostOwner(postRepo repository.PostRepository) gin.HandlerFunc {
 		}
 
 		// 게시글 조회
-		post, err := postRepo.FindByID(c.Request.Context(), uint(postID))
+		post, err := postRepo.FindByID(uint(postID))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
 				"error": "게시글을 찾을 수 없습니다",
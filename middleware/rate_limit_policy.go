@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKeyBy는 Rate Limit을 적용할 대상입니다.
+type RateLimitKeyBy string
+
+const (
+	RateLimitByIP     RateLimitKeyBy = "ip"      // 클라이언트 IP
+	RateLimitByUser   RateLimitKeyBy = "user"    // 로그인 사용자 (비로그인이면 IP)
+	RateLimitByAPIKey RateLimitKeyBy = "api_key" // Personal Access Token별 (아니면 사용자, 비로그인이면 IP)
+)
+
+// rateLimitKeyPrefix는 Redis 키 접두사입니다.
+const rateLimitKeyPrefix = "ratelimit"
+
+// RateLimitPolicy는 라우트 그룹에 적용할 Rate Limit입니다.
+// Period 동안 Limit회를 고르게 허용하고, 최대 Burst회까지 몰아서 보낼 수 있습니다.
+type RateLimitPolicy struct {
+	Name     string // 키 구분용 (그룹마다 다른 한도 적용)
+	Limit    int
+	Period   time.Duration
+	Burst    int // 0이면 Limit
+	KeyBy    RateLimitKeyBy
+	FailOpen bool // 저장소(Redis) 장애 시 요청 허용 (false면 503)
+}
+
+// interval은 요청 하나가 회복되는 시간입니다.
+func (p RateLimitPolicy) interval() time.Duration {
+	return p.Period / time.Duration(p.Limit)
+}
+
+func (p RateLimitPolicy) burst() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// Validate는 정책 값을 검사합니다.
+func (p RateLimitPolicy) Validate() error {
+	if p.Limit <= 0 || p.Period <= 0 {
+		return fmt.Errorf("rate limit %q: limit and period must be positive", p.Name)
+	}
+	if p.interval() < time.Millisecond {
+		return fmt.Errorf("rate limit %q: limit per period too high (max 1 per ms)", p.Name)
+	}
+	switch p.KeyBy {
+	case RateLimitByIP, RateLimitByUser, RateLimitByAPIKey:
+		return nil
+	default:
+		return fmt.Errorf("rate limit %q: unknown key %q", p.Name, p.KeyBy)
+	}
+}
+
+// RateLimits는 라우트 그룹별 Rate Limit 정책 모음입니다.
+type RateLimits struct {
+	store    RateLimitStore
+	policies map[string]RateLimitPolicy
+}
+
+// NewRateLimits는 정책을 검사하고 RateLimits를 만듭니다.
+func NewRateLimits(store RateLimitStore, policies []RateLimitPolicy) (*RateLimits, error) {
+	byName := make(map[string]RateLimitPolicy, len(policies))
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		byName[policy.Name] = policy
+	}
+	return &RateLimits{store: store, policies: byName}, nil
+}
+
+// For는 name 정책의 Rate Limit 미들웨어를 반환합니다.
+// 정책이 없으면 제한 없이 통과시키고 경고를 남깁니다. (설정에서 정책을 지운 경우)
+//
+//	user/api_key 정책은 AuthMiddleware(또는 OptionalAuthMiddleware) 뒤에 등록해야 사용자로 구분됩니다.
+func (l *RateLimits) For(name string) gin.HandlerFunc {
+	policy, ok := l.policies[name]
+	if !ok {
+		slog.Warn("rate limit policy not found - requests are not limited", "policy", name)
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	interval, burst := policy.interval(), policy.burst()
+	return func(c *gin.Context) {
+		key := fmt.Sprintf("%s:%s:%s", rateLimitKeyPrefix, policy.Name, rateLimitSubject(c, policy.KeyBy))
+
+		result, err := l.store.Allow(c.Request.Context(), key, interval, burst)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "rate limit store failed", "policy", policy.Name, "fail_open", policy.FailOpen, "error", err)
+			if policy.FailOpen {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "RATE_LIMIT_UNAVAILABLE",
+					"message": "잠시 후 다시 시도해주세요.",
+				},
+			})
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":        "RATE_LIMITED",
+					"message":     "요청이 너무 많습니다. 잠시 후 다시 시도해주세요.",
+					"retry_after": retryAfter,
+				},
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitSubject는 요청을 구분하는 키입니다. 사용자/토큰을 알 수 없으면 IP로 대신합니다.
+func rateLimitSubject(c *gin.Context, keyBy RateLimitKeyBy) string {
+	if keyBy != RateLimitByIP {
+		if claims, ok := GetCurrentUser(c); ok {
+			if keyBy == RateLimitByAPIKey && claims.IsPersonalToken() {
+				return "pat:" + strconv.FormatUint(uint64(claims.PersonalTokenID), 10)
+			}
+			return "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds는 헤더용으로 초 단위 올림 값을 반환합니다.
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
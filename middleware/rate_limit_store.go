@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitResult는 요청 하나에 대한 Rate Limit 판단 결과입니다.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int           // 지금 바로 더 보낼 수 있는 요청 수
+	RetryAfter time.Duration // 거부된 경우 다음 요청이 허용될 때까지 남은 시간
+	ResetAfter time.Duration // 한도가 완전히 회복될 때까지 남은 시간
+}
+
+// RateLimitStore는 키별 요청 허용 여부를 판단하는 저장소입니다. (GCRA)
+//
+//	GCRA(Generic Cell Rate Algorithm)는 키마다 "이론상 다음 도착 시각(TAT)" 하나만 저장하고,
+//	interval마다 요청 하나씩, 최대 burst개까지 몰아서 허용합니다. (슬라이딩 윈도처럼 경계에서 두 배가 허용되지 않음)
+//
+//	운영(여러 인스턴스): RedisRateLimitStore - 모든 인스턴스가 한도를 공유
+//	로컬 개발/단일 인스턴스: MemoryRateLimitStore
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string, interval time.Duration, burst int) (*RateLimitResult, error)
+}
+
+// MemoryRateLimitStore는 메모리 기반 RateLimitStore입니다.
+// 단일 인스턴스 전용 - 여러 대로 운영하면 인스턴스마다 한도가 따로 적용됩니다.
+type MemoryRateLimitStore struct {
+	mu  sync.Mutex
+	tat map[string]time.Time // key -> 이론상 다음 도착 시각
+	now func() time.Time
+
+	nextCleanup time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		tat: make(map[string]time.Time),
+		now: time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Allow(ctx context.Context, key string, interval time.Duration, burst int) (*RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.cleanup(now)
+
+	tat, ok := s.tat[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	result := gcra(now, tat, interval, burst)
+	if result.Allowed {
+		s.tat[key] = tat.Add(interval)
+	}
+	return result, nil
+}
+
+// cleanup은 한도가 완전히 회복된(TAT가 지난) 키를 주기적으로 삭제합니다.
+func (s *MemoryRateLimitStore) cleanup(now time.Time) {
+	if now.Before(s.nextCleanup) {
+		return
+	}
+	for key, tat := range s.tat {
+		if tat.Before(now) {
+			delete(s.tat, key)
+		}
+	}
+	s.nextCleanup = now.Add(time.Minute)
+}
+
+// gcra는 현재 TAT(now 이후로 보정된 값)로 요청 허용 여부를 계산합니다. (redisRateLimitScript와 같은 계산)
+func gcra(now, tat time.Time, interval time.Duration, burst int) *RateLimitResult {
+	tolerance := interval * time.Duration(burst)
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-tolerance)
+
+	if allowAt.After(now) {
+		return &RateLimitResult{
+			Allowed:    false,
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}
+	}
+	return &RateLimitResult{
+		Allowed:    true,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock은 토큰 버킷 회복을 시간 대기 없이 확인하기 위한 시계입니다.
+type fakeClock struct {
+	mu       sync.Mutex
+	now      time.Time
+	onChange func(now time.Time, d time.Duration) // Redis 구현은 miniredis 시각도 함께 옮김
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	if c.onChange != nil {
+		c.onChange(c.now, d)
+	}
+}
+
+// rateLimitStores는 같은 동작을 검증할 RateLimitStore 구현 목록입니다.
+var rateLimitStores = []struct {
+	name string
+	new  func(t *testing.T) (RateLimitStore, *fakeClock)
+}{
+	{"memory", func(t *testing.T) (RateLimitStore, *fakeClock) {
+		clock := &fakeClock{now: time.Now()}
+		store := NewMemoryRateLimitStore()
+		store.now = clock.Now
+		return store, clock
+	}},
+	{"redis", func(t *testing.T) (RateLimitStore, *fakeClock) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { client.Close() })
+
+		// Lua 스크립트가 Redis TIME으로 계산하므로 miniredis 시각을 고정
+		clock := &fakeClock{now: time.Now().Truncate(time.Millisecond)}
+		mr.SetTime(clock.now)
+		clock.onChange = func(now time.Time, d time.Duration) {
+			mr.SetTime(now)
+			mr.FastForward(d) // 키 만료
+		}
+		return NewRedisRateLimitStore(client), clock
+	}},
+}
+
+// eachStore는 구현마다 새 저장소로 fn을 실행합니다.
+func eachStore(t *testing.T, fn func(t *testing.T, store RateLimitStore, clock *fakeClock)) {
+	for _, impl := range rateLimitStores {
+		t.Run(impl.name, func(t *testing.T) {
+			store, clock := impl.new(t)
+			fn(t, store, clock)
+		})
+	}
+}
+
+// allow는 초당 1회, burst 3 정책으로 요청 하나를 처리합니다.
+func allow(t *testing.T, store RateLimitStore, key string) *RateLimitResult {
+	t.Helper()
+	result, err := store.Allow(context.Background(), key, time.Second, 3)
+	require.NoError(t, err)
+	return result
+}
+
+func TestRateLimitStore_BurstThenDeny(t *testing.T) {
+	eachStore(t, func(t *testing.T, store RateLimitStore, _ *fakeClock) {
+		for want := 2; want >= 0; want-- {
+			result := allow(t, store, "k")
+			assert.True(t, result.Allowed)
+			assert.Equal(t, want, result.Remaining)
+		}
+
+		result := allow(t, store, "k")
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.ResetAfter)
+	})
+}
+
+func TestRateLimitStore_RecoversOnePerInterval(t *testing.T) {
+	eachStore(t, func(t *testing.T, store RateLimitStore, clock *fakeClock) {
+		for range 3 {
+			require.True(t, allow(t, store, "k").Allowed)
+		}
+		require.False(t, allow(t, store, "k").Allowed)
+
+		clock.Advance(time.Second)
+		assert.True(t, allow(t, store, "k").Allowed)
+		assert.False(t, allow(t, store, "k").Allowed)
+
+		// 완전히 회복되면 다시 burst만큼
+		clock.Advance(10 * time.Second)
+		for range 3 {
+			assert.True(t, allow(t, store, "k").Allowed)
+		}
+	})
+}
+
+func TestRateLimitStore_DeniedRequestsDoNotConsume(t *testing.T) {
+	eachStore(t, func(t *testing.T, store RateLimitStore, clock *fakeClock) {
+		for range 3 {
+			allow(t, store, "k")
+		}
+		for range 5 {
+			require.False(t, allow(t, store, "k").Allowed)
+		}
+
+		clock.Advance(time.Second)
+		assert.True(t, allow(t, store, "k").Allowed)
+	})
+}
+
+func TestRateLimitStore_KeysAreIndependent(t *testing.T) {
+	eachStore(t, func(t *testing.T, store RateLimitStore, _ *fakeClock) {
+		for range 3 {
+			allow(t, store, "a")
+		}
+		assert.False(t, allow(t, store, "a").Allowed)
+		assert.True(t, allow(t, store, "b").Allowed)
+	})
+}
+
+func TestRateLimitStore_ConcurrentRequestsRespectBurst(t *testing.T) {
+	eachStore(t, func(t *testing.T, store RateLimitStore, _ *fakeClock) {
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := store.Allow(context.Background(), "k", time.Minute, 5)
+				if assert.NoError(t, err) && result.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 5, allowed)
+	})
+}
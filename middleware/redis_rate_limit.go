@@ -1,56 +1,62 @@
 package middleware
 
-// Redis를 이용한 분산 Rate Limit
+import (
+	"context"
+	"time"
 
-//type RedisRateLimiter struct {
-//	client *redis.Client
-//	limit  int
-//	window time.Duration
-//}
-//
-//func NewRedisRateLimiter(client *redis.Client, limit int, window time.Duration) *RedisRateLimiter {
-//	return &RedisRateLimiter{
-//		client: client,
-//		limit:  limit,
-//		window: window,
-//	}
-//}
-//
-//func (rl *RedisRateLimiter) Middleware() gin.HandlerFunc {
-//	return func(c *gin.Context) {
-//		ctx := context.Background()
-//		ip := c.ClientIP()
-//		key := fmt.Sprintf("rate_limit:%s", ip)
-//
-//		// 현재 카운트 조회 및 증가
-//		count, err := rl.client.Incr(ctx, key).Result()
-//		if err != nil {
-//			c.Next() // Redis 에러 시 허용
-//			return
-//		}
-//
-//		// 첫 요청이면 만료 시간 설정
-//		if count == 1 {
-//			rl.client.Expire(ctx, key, rl.window)
-//		}
-//
-//		// 제한 초과 확인
-//		if count > int64(rl.limit) {
-//			ttl, _ := rl.client.TTL(ctx, key).Result()
-//			c.Header("Retry-After", fmt.Sprintf("%d", int(ttl.Seconds())+1))
-//			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-//				"success": false,
-//				"error": gin.H{
-//					"code":    "RATE_LIMITED",
-//					"message": "요청이 너무 많습니다.",
-//				},
-//			})
-//			return
-//		}
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis를 이용한 분산 Rate Limit (GCRA)
+
+// redisRateLimitScript는 GCRA를 원자적으로 계산합니다.
 //
-//		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", rl.limit))
-//		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", rl.limit-int(count)))
+//	GET/SET 사이에 다른 인스턴스의 요청이 끼어들 수 없고 (INCR/EXPIRE 고정 윈도 방식의 경쟁 조건 없음),
+//	시각은 Redis 서버의 TIME을 사용해 API 서버 간 시계 차이의 영향을 받지 않습니다.
 //
-//		c.Next()
-//	}
-//}
+//	KEYS[1] = 키, ARGV[1] = interval(ms), ARGV[2] = burst
+//	반환: {허용 여부(1/0), 남은 요청 수, retry_after(ms), reset_after(ms)}
+var redisRateLimitScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local tolerance = interval * tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local tat = tonumber(redis.call("GET", KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - tolerance
+if allow_at > now then
+	return {0, 0, allow_at - now, tat - now}
+end
+
+redis.call("SET", KEYS[1], new_tat, "PX", new_tat - now)
+return {1, math.floor((now - allow_at) / interval), 0, new_tat - now}
+`)
+
+// RedisRateLimitStore는 Redis 기반 RateLimitStore입니다. 여러 서버가 한도를 공유합니다.
+type RedisRateLimitStore struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimitStore(client *redis.Client) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+func (s *RedisRateLimitStore) Allow(ctx context.Context, key string, interval time.Duration, burst int) (*RateLimitResult, error) {
+	values, err := redisRateLimitScript.Run(ctx, s.client, []string{key}, interval.Milliseconds(), burst).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
@@ -27,3 +27,23 @@ func RequestID() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// CaptureRequestInfo는 IP, User-Agent, 요청 ID를 Go Context에 저장합니다. [ 서비스의 감사 로그용 ]
+// RequestID 뒤에 등록해야 요청 ID가 함께 기록됩니다.
+func CaptureRequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetString("requestID")
+		if requestID == "" {
+			requestID = c.GetHeader(RequestIDHeader)
+		}
+
+		ctx := SetRequestInfoToContext(c.Request.Context(), RequestInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: requestID,
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
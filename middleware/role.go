@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"gorm-test/internal/auth"
+	"gorm-test/internal/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -32,3 +34,37 @@ func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePermission은 역할에 지정한 권한이 모두 있어야 통과하는 미들웨어입니다.
+// 역할별 권한은 DB에서 관리하므로 checker로 확인합니다.
+func RequirePermission(checker auth.PermissionChecker, permissions ...domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		for _, permission := range permissions {
+			allowed, err := checker.HasPermission(c.Request.Context(), claims.Role, permission)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "서버 오류가 발생했습니다",
+				})
+				return
+			}
+			if !allowed {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":      "권한이 없습니다",
+					"code":       "PERMISSION_DENIED",
+					"permission": permission,
+				})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
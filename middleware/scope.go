@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope는 Personal Access Token에 지정한 scope가 모두 있어야 통과하는 미들웨어입니다.
+// 로그인(JWT)으로 인증한 요청은 사용자 권한을 모두 가지므로 그대로 통과합니다.
+// 역할 확인은 RequireRole과 함께 사용합니다.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": "토큰에 필요한 권한(scope)이 없습니다",
+					"code":  "INSUFFICIENT_SCOPE",
+					"scope": scope,
+				})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSessionAuth는 로그인(JWT)으로 인증한 요청만 허용하는 미들웨어입니다.
+// 계정/토큰 관리처럼 Personal Access Token으로 하면 안 되는 작업에 사용합니다.
+func RequireSessionAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		if claims.IsPersonalToken() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "Personal Access Token으로는 사용할 수 없습니다. 로그인 후 다시 시도해주세요",
+				"code":  "SESSION_REQUIRED",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AccessTokenCookie는 쿠키 세션의 Access Token 쿠키 이름입니다.
+	AccessTokenCookie = "access_token"
+	// RefreshTokenCookie는 쿠키 세션의 Refresh Token 쿠키 이름입니다.
+	RefreshTokenCookie = "refresh_token"
+	// CSRFTokenCookie는 CSRF 토큰 쿠키 이름입니다. (스크립트에서 읽을 수 있음)
+	CSRFTokenCookie = "csrf_token"
+	// CSRFHeader는 CSRF 토큰을 다시 보내는 헤더 이름입니다.
+	CSRFHeader = "X-CSRF-Token"
+	// SessionModeHeader는 로그인 시 쿠키 세션을 요청하는 헤더입니다. (값: cookie)
+	SessionModeHeader = "X-Session-Mode"
+)
+
+// SessionCookies는 브라우저용 쿠키 세션 설정입니다.
+//
+//	토큰은 HttpOnly 쿠키로 보내고, 안전하지 않은 메서드(POST/PUT/PATCH/DELETE)는
+//	CSRF 토큰 쿠키 값을 X-CSRF-Token 헤더로 다시 보내야 합니다. (double-submit)
+type SessionCookies struct {
+	Domain      string
+	Secure      bool
+	SameSite    http.SameSite
+	MaxAge      time.Duration // Refresh Token 만료 시간과 같게 설정
+	RefreshPath string        // Refresh Token 쿠키는 인증 API에만 전송
+}
+
+// Set은 토큰 쿠키와 새 CSRF 토큰 쿠키를 설정하고 CSRF 토큰을 반환합니다.
+// Access Token 쿠키도 MaxAge 동안 유지해서, 만료되면 TOKEN_EXPIRED 응답을 받고 갱신할 수 있게 합니다.
+func (s SessionCookies) Set(c *gin.Context, accessToken, refreshToken string) (string, error) {
+	csrfToken, err := newCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	maxAge := int(s.MaxAge.Seconds())
+	s.set(c, AccessTokenCookie, accessToken, "/", maxAge, true)
+	s.set(c, RefreshTokenCookie, refreshToken, s.refreshPath(), maxAge, true)
+	s.set(c, CSRFTokenCookie, csrfToken, "/", maxAge, false)
+	return csrfToken, nil
+}
+
+// Clear는 쿠키 세션의 쿠키를 모두 삭제합니다.
+func (s SessionCookies) Clear(c *gin.Context) {
+	s.set(c, AccessTokenCookie, "", "/", -1, true)
+	s.set(c, RefreshTokenCookie, "", s.refreshPath(), -1, true)
+	s.set(c, CSRFTokenCookie, "", "/", -1, false)
+}
+
+func (s SessionCookies) set(c *gin.Context, name, value, path string, maxAge int, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.Domain,
+		MaxAge:   maxAge,
+		Secure:   s.Secure,
+		HttpOnly: httpOnly,
+		SameSite: s.SameSite,
+	})
+}
+
+func (s SessionCookies) refreshPath() string {
+	if s.RefreshPath == "" {
+		return "/"
+	}
+	return s.RefreshPath
+}
+
+// ParseSameSite는 설정 값(lax, strict, none)을 http.SameSite로 바꿉니다. 기본값은 Lax입니다.
+// none은 Secure 쿠키에서만 동작합니다.
+func ParseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// WantsCookieSession은 요청이 쿠키 세션을 원하는지 확인합니다.
+func WantsCookieSession(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader(SessionModeHeader), "cookie")
+}
+
+// ValidCSRF는 안전한 메서드이거나, CSRF 헤더가 CSRF 쿠키와 같으면 true입니다.
+func ValidCSRF(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	cookie, err := c.Cookie(CSRFTokenCookie)
+	header := c.GetHeader(CSRFHeader)
+	if err != nil || cookie == "" || header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) == 1
+}
+
+func abortCSRF(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": "CSRF 토큰이 없거나 올바르지 않습니다",
+		"code":  "INVALID_CSRF_TOKEN",
+	})
+}
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gorm-test/internal/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestTokenService는 메모리 키/세션 저장소를 쓰는 TokenService를 만듭니다.
+func newTestTokenService(t *testing.T) *auth.TokenService {
+	t.Helper()
+	keys, err := auth.NewKeyManager(context.Background(), auth.NewMemoryKeyStore(), auth.KeyManagerConfig{Algorithm: auth.AlgEdDSA})
+	require.NoError(t, err)
+
+	store := auth.NewMemoryTokenStore(time.Hour)
+	t.Cleanup(store.Close)
+	return auth.NewTokenService(keys, 15*time.Minute, 24*time.Hour, store)
+}
+
+// issueAccessToken은 새 세션의 Access Token을 발급합니다.
+func issueAccessToken(t *testing.T, tokens *auth.TokenService, userID uint, role string) string {
+	t.Helper()
+	_, session, err := tokens.CreateSession(context.Background(), userID, auth.SessionMeta{})
+	require.NoError(t, err)
+
+	token, err := tokens.GenerateAccessToken(auth.TokenSubject{UserID: userID, Email: "user@example.com", Role: role}, session)
+	require.NoError(t, err)
+	return token
+}
+
+func responseCookies(w *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func TestSessionCookies_Set(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	cookies := SessionCookies{Secure: true, SameSite: http.SameSiteStrictMode, MaxAge: time.Hour, RefreshPath: "/api/v1/api/auths"}
+	csrfToken, err := cookies.Set(c, "access", "refresh")
+	require.NoError(t, err)
+
+	got := responseCookies(w)
+	require.Len(t, got, 3)
+
+	// 토큰은 스크립트에서 읽을 수 없고, CSRF 토큰만 읽을 수 있음
+	assert.True(t, got[AccessTokenCookie].HttpOnly)
+	assert.True(t, got[RefreshTokenCookie].HttpOnly)
+	assert.False(t, got[CSRFTokenCookie].HttpOnly)
+	assert.Equal(t, csrfToken, got[CSRFTokenCookie].Value)
+
+	assert.Equal(t, "/", got[AccessTokenCookie].Path)
+	assert.Equal(t, "/api/v1/api/auths", got[RefreshTokenCookie].Path)
+	for _, cookie := range got {
+		assert.True(t, cookie.Secure, cookie.Name)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite, cookie.Name)
+		assert.Equal(t, 3600, cookie.MaxAge, cookie.Name)
+	}
+
+	// 로그인마다 새 CSRF 토큰
+	other, err := cookies.Set(c, "access", "refresh")
+	require.NoError(t, err)
+	assert.NotEqual(t, csrfToken, other)
+}
+
+func TestSessionCookies_Clear(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	SessionCookies{}.Clear(c)
+
+	got := responseCookies(w)
+	require.Len(t, got, 3)
+	for _, cookie := range got {
+		assert.Empty(t, cookie.Value, cookie.Name)
+		assert.Negative(t, cookie.MaxAge, cookie.Name)
+	}
+}
+
+func TestValidCSRF(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		cookie string
+		header string
+		want   bool
+	}{
+		{"안전한 메서드", http.MethodGet, "", "", true},
+		{"쿠키와 헤더 일치", http.MethodPost, "token", "token", true},
+		{"헤더 없음", http.MethodPost, "token", "", false},
+		{"쿠키 없음", http.MethodDelete, "", "token", false},
+		{"불일치", http.MethodPut, "token", "other", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(tc.method, "/", nil)
+			if tc.cookie != "" {
+				c.Request.AddCookie(&http.Cookie{Name: CSRFTokenCookie, Value: tc.cookie})
+			}
+			if tc.header != "" {
+				c.Request.Header.Set(CSRFHeader, tc.header)
+			}
+			assert.Equal(t, tc.want, ValidCSRF(c))
+		})
+	}
+}
+
+func TestAuthMiddleware_CookieRequestsRequireCSRF(t *testing.T) {
+	tokens := newTestTokenService(t)
+	accessToken := issueAccessToken(t, tokens, 1, "user")
+
+	r := gin.New()
+	r.Use(AuthMiddleware(tokens))
+	r.Any("/me", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	serve := func(method string, setup func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/me", nil)
+		setup(req)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	withCookies := func(csrfHeader string) func(req *http.Request) {
+		return func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: accessToken})
+			req.AddCookie(&http.Cookie{Name: CSRFTokenCookie, Value: "csrf"})
+			if csrfHeader != "" {
+				req.Header.Set(CSRFHeader, csrfHeader)
+			}
+		}
+	}
+
+	// 브라우저가 자동으로 보내는 쿠키만으로는 상태 변경 불가
+	w := serve(http.MethodPost, withCookies(""))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CSRF_TOKEN")
+
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, withCookies("forged")).Code)
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, withCookies("csrf")).Code)
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodGet, withCookies("")).Code)
+
+	// Authorization 헤더는 브라우저가 자동으로 붙이지 않으므로 CSRF 확인 없음
+	w = serve(http.MethodPost, func(req *http.Request) {
+		req.Header.Set(AuthorizationHeader, AuthorizationType+" "+accessToken)
+	})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestParseSameSite(t *testing.T) {
+	assert.Equal(t, http.SameSiteStrictMode, ParseSameSite("Strict"))
+	assert.Equal(t, http.SameSiteNoneMode, ParseSameSite("none"))
+	assert.Equal(t, http.SameSiteLaxMode, ParseSameSite("lax"))
+	assert.Equal(t, http.SameSiteLaxMode, ParseSameSite(""))
+}
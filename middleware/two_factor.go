@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireTwoFactor는 2단계 인증을 거친 세션만 허용하는 미들웨어입니다.
+// AuthMiddleware 뒤에 등록해야 합니다.
+func RequireTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		if !claims.TwoFactor {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "2단계 인증을 등록하고 다시 로그인해주세요",
+				"code":  "TWO_FACTOR_REQUIRED",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
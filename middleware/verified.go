@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireVerifiedEmail은 이메일 인증 전 사용자를 읽기 전용으로 제한하는 미들웨어입니다.
+// 조회(GET, HEAD, OPTIONS)는 허용하고 작성/수정/삭제 요청은 거부합니다.
+// AuthMiddleware 뒤에 등록해야 합니다.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		claims, ok := GetCurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "인증이 필요합니다",
+			})
+			return
+		}
+
+		if !claims.EmailVerified {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "이메일 인증 후 이용할 수 있습니다",
+				"code":  "EMAIL_NOT_VERIFIED",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -76,6 +76,18 @@ func Conflict(message string) *AppError {
 	}
 }
 
+// Locked는 리소스가 잠겨 있어 요청을 처리할 수 없을 때 사용합니다 (423)
+func Locked(message string) *AppError {
+	if message == "" {
+		message = "잠긴 리소스입니다"
+	}
+	return &AppError{
+		HTTPStatus: http.StatusLocked,
+		Code:       "LOCKED",
+		Message:    message,
+	}
+}
+
 // InternalError는 서버 내부 오류일 때 사용합니다
 func InternalError(err error) *AppError {
 	return &AppError{
@@ -8,11 +8,16 @@ import (
 // LogError는 에러를 구조화된 형태로 로깅합니다
 func LogError(log *slog.Logger, err error) {
 	// AppError인 경우 추가 정보 포함
-	if appErr, ok := err.(*apperror.apperror); ok {
+	if appErr, ok := apperror.AsAppError(err); ok {
 		attrs := []any{
 			"error_code", appErr.Code,
-			"context", appErr.Context,
-			"stack_trace", appErr.StackTrace(),
+			"status", appErr.HTTPStatus,
+		}
+		if appErr.Detail != "" {
+			attrs = append(attrs, "detail", appErr.Detail)
+		}
+		if len(appErr.Fields) > 0 {
+			attrs = append(attrs, "fields", appErr.Fields)
 		}
 		if appErr.Err != nil {
 			attrs = append(attrs, "error", appErr.Err)
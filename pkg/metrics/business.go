@@ -33,6 +33,24 @@ var (
 		[]string{"status"}, // success, failure
 	)
 
+	// 보안 이벤트 카운터
+	SecurityEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "board_security_events_total",
+			Help: "Total number of security events",
+		},
+		[]string{"event"}, // refresh_token_reuse 등
+	)
+
+	// 콘텐츠 필터 판정 카운터
+	ContentFilterDecisions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "board_content_filter_decisions_total",
+			Help: "Total number of content filter decisions",
+		},
+		[]string{"target", "filter", "decision"}, // post/comment, 필터 이름, allow/hold/reject
+	)
+
 	// 데이터베이스 쿼리 시간
 	DBQueryDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -40,6 +40,10 @@ func getTypeFromCode(code string) string {
 		return TypeForbidden
 	case "CONFLICT":
 		return TypeConflict
+	case "LOCKED":
+		return TypeLocked
+	case "CONTENT_REJECTED":
+		return TypeUnprocessable
 	default:
 		return TypeInternalError
 	}
@@ -9,6 +9,8 @@ const (
 	TypeUnauthorized  = BaseURI + "/unauthorized"
 	TypeForbidden     = BaseURI + "/forbidden"
 	TypeConflict      = BaseURI + "/conflict"
+	TypeLocked        = BaseURI + "/locked"
+	TypeUnprocessable = BaseURI + "/unprocessable"
 	TypeInternalError = BaseURI + "/internal-error"
 	TypeRateLimited   = BaseURI + "/rate-limited"
 )
@@ -15,10 +15,10 @@ func Init(dsn string) error {
 }
 
 func CaptureError(err error) {
-	if appErr, ok := err.(*apperror.apperror); ok {
+	if appErr, ok := apperror.AsAppError(err); ok {
 		sentry.WithScope(func(scope *sentry.Scope) {
 			scope.SetExtra("error_code", appErr.Code)
-			scope.SetExtra("context", appErr.Context)
+			scope.SetExtra("detail", appErr.Detail)
 			sentry.CaptureException(appErr)
 		})
 	} else {
@@ -4,9 +4,18 @@ import (
 	"regexp"
 	"unicode"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
+// RegisterGinValidations는 Gin의 요청 바인딩(binding 태그)에 커스텀 검증을 등록합니다.
+// 등록하지 않으면 safe_string 등을 쓰는 요청을 바인딩할 때 panic이 발생합니다.
+func RegisterGinValidations() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		RegisterCustomValidations(v)
+	}
+}
+
 func RegisterCustomValidations(v *validator.Validate) {
 	// 안전한 문자열 (스크립트 태그 없음)
 	v.RegisterValidation("safe_string", validateSafeString)